@@ -1,6 +1,7 @@
 package ptp
 
 import (
+	"bytes"
 	"encoding/binary"
 	"io"
 )
@@ -16,46 +17,67 @@ type AnnounceMsg struct {
 	StepsRemoved     uint16
 	TimeSource       TimeSourceType
 	PathTraceTlv
+	// IEEE C37.238 power profile TLVs, nil if not present
+	PowerProfile     *PowerProfileTlv
+	PowerProfile2011 *PowerProfile2011Tlv
 }
 
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t AnnounceMsg) MarshalBinary() ([]byte, error) {
-	if t.Header.MessageType != AnnounceMsgType {
-		return nil, ErrInvalidMsgType
-	}
 
-	tlvSlice, err := t.PathTraceTlv.MarshalBinary()
-	if err != nil {
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
 		return nil, err
 	}
 
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+	return b, nil
+}
+
+// MarshalLen returns the length of a AnnounceMsg in binary form.
+func (t AnnounceMsg) MarshalLen() int {
+	return HeaderLen + AnnouncePayloadLen + t.tlvsLen()
+}
+
+// AppendBinary appends the binary form of a AnnounceMsg to b.
+func (t AnnounceMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a AnnounceMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t AnnounceMsg) MarshalTo(b []byte) (int, error) {
+	if t.Header.MessageType != AnnounceMsgType {
+		return 0, ErrInvalidMsgType
 	}
 
-	b := make([]byte, HeaderLen+AnnouncePayloadLen+len(tlvSlice))
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
 
-	copy(b[:HeaderLen], headerSlice)
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 	offset := HeaderLen
 
 	// Reserved 10 bytes
+	zero(b[offset : offset+Reserved10])
 	offset += Reserved10
 
 	binary.BigEndian.PutUint16(b[offset:offset+CurrentUtcOffsetLen], uint16(t.CurrentUtcOffset))
 	offset += CurrentUtcOffsetLen
 
 	// Reserved byte
+	b[offset] = 0
 	offset++
 
 	b[offset] = t.GMPriority1
 	offset++
 
-	gmClockQualitySlice, err := t.GMClockQuality.MarshalBinary()
-	if err != nil {
-		return nil, err
+	if _, err := t.GMClockQuality.MarshalTo(b[offset : offset+ClockQualityPayloadLen]); err != nil {
+		return 0, err
 	}
-	copy(b[offset:offset+ClockQualityPayloadLen], gmClockQualitySlice)
 	offset += ClockQualityPayloadLen
 
 	b[offset] = t.GMPriority2
@@ -70,23 +92,65 @@ func (t AnnounceMsg) MarshalBinary() ([]byte, error) {
 	b[offset] = uint8(t.TimeSource)
 	offset++
 
-	copy(b[offset:offset+len(tlvSlice)], tlvSlice)
+	m, err := t.PathTraceTlv.MarshalTo(b[offset:n])
+	if err != nil {
+		return 0, err
+	}
+	offset += m
+
+	if t.PowerProfile != nil {
+		m, err := t.PowerProfile.MarshalTo(b[offset:n])
+		if err != nil {
+			return 0, err
+		}
+		offset += m
+	}
 
-	return b, nil
+	if t.PowerProfile2011 != nil {
+		if _, err := t.PowerProfile2011.MarshalTo(b[offset:n]); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// tlvsLen returns the length of TLVs following the Announce body.
+func (t AnnounceMsg) tlvsLen() int {
+	n := t.PathTraceTlv.MarshalLen()
+
+	if t.PowerProfile != nil {
+		n += t.PowerProfile.MarshalLen()
+	}
+
+	if t.PowerProfile2011 != nil {
+		n += t.PowerProfile2011.MarshalLen()
+	}
+
+	return n
 }
 
 // UnmarshalBinary unmarshals a byte slice into a Frame.
+//
+// Unknown TLVs are skipped, trailing bytes too short to hold a TLV are
+// ignored.
 func (t *AnnounceMsg) UnmarshalBinary(b []byte) error {
-	if len(b) < HeaderLen+AnnouncePayloadLen {
-		return io.ErrUnexpectedEOF
-	}
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
+
+// unmarshal decodes an AnnounceMsg accordingly with the options.
+func (t *AnnounceMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, AnnounceMsgType, HeaderLen+AnnouncePayloadLen)
 	if err != nil {
 		return err
 	}
 
+	if err = t.Header.unmarshal(b, o); err != nil {
+		return err
+	}
+
 	if t.Header.MessageType != AnnounceMsgType {
-		return ErrInvalidMsgType
+		return decodeError(AnnounceMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
 	}
 
 	offset := HeaderLen
@@ -99,14 +163,23 @@ func (t *AnnounceMsg) UnmarshalBinary(b []byte) error {
 	offset += 2
 
 	// Reserved byte
+	if err = o.reserved(&t.Header, b[offset:offset+1], offset); err != nil {
+		return err
+	}
 	offset++
 
 	t.GMPriority1 = b[offset]
 	offset++
 
-	err = t.GMClockQuality.UnmarshalBinary(b[offset : offset+ClockQualityPayloadLen])
-	if err != nil {
-		return err
+	err = t.GMClockQuality.unmarshal(b[offset:offset+ClockQualityPayloadLen], o)
+	switch err {
+	case nil:
+	case ErrInvalidClockClass:
+		return decodeError(AnnounceMsgType, "grandmasterClockQuality.clockClass", offset, b[offset], err)
+	case ErrInvalidClockAccuracy:
+		return decodeError(AnnounceMsgType, "grandmasterClockQuality.clockAccuracy", offset+1, b[offset+1], err)
+	default:
+		return decodeError(AnnounceMsgType, "grandmasterClockQuality", offset, nil, err)
 	}
 	offset += ClockQualityPayloadLen
 
@@ -120,8 +193,81 @@ func (t *AnnounceMsg) UnmarshalBinary(b []byte) error {
 	offset += StepsRemovedLen
 
 	t.TimeSource = TimeSourceType(b[offset])
-	if !isValidTimeSource(t.TimeSource) {
-		return ErrInvalidTimeSource
+	if !o.AllowUnknownValues && !isValidTimeSource(t.TimeSource) {
+		return decodeError(AnnounceMsgType, "timeSource", offset, t.TimeSource, ErrInvalidTimeSource)
+	}
+	offset++
+
+	return t.unmarshalTlvs(b[offset:], offset, o)
+}
+
+// unmarshalTlvs decodes the TLVs following the Announce body. Unknown TLVs
+// are skipped. b starts at offset of the message.
+func (t *AnnounceMsg) unmarshalTlvs(b []byte, offset int, o *DecodeOptions) error {
+	t.PathTraceTlv = PathTraceTlv{}
+	t.PowerProfile = nil
+	t.PowerProfile2011 = nil
+
+	return o.walkTlvs(b, AnnounceMsgType, offset, func(tlvType TlvType, tlv []byte) error {
+		switch tlvType {
+		case PathTrace:
+			// MarshalBinary always sends the TLV, even if it is empty
+			if len(tlv) == 4 {
+				return nil
+			}
+			return t.PathTraceTlv.UnmarshalBinary(tlv)
+		case OrganizationExtension:
+			if len(tlv) < 10 || !bytes.Equal(tlv[4:7], ieeeC37238OrganizationID) {
+				return nil
+			}
+
+			switch {
+			case bytes.Equal(tlv[7:10], []byte{0x0, 0x0, 0x1}):
+				t.PowerProfile2011 = new(PowerProfile2011Tlv)
+				return t.PowerProfile2011.UnmarshalBinary(tlv)
+			case bytes.Equal(tlv[7:10], []byte{0x0, 0x0, 0x2}):
+				t.PowerProfile = new(PowerProfileTlv)
+				return t.PowerProfile.UnmarshalBinary(tlv)
+			}
+		}
+
+		return nil
+	})
+}
+
+// AddTimeInaccuracy accumulates the time inaccuracy in nanoseconds introduced
+// by a boundary clock into the IEEE C37.238 TLVs present in the message. It is
+// intended to be called before the Announce information is transmitted on
+// master ports.
+func (t *AnnounceMsg) AddTimeInaccuracy(ns uint32) {
+	if t.PowerProfile != nil {
+		t.PowerProfile.AddTimeInaccuracy(ns)
+	}
+
+	if t.PowerProfile2011 != nil {
+		t.PowerProfile2011.AddNetworkTimeInaccuracy(ns)
+	}
+}
+
+// Validate checks that an AnnounceMsg conforms to IEEE 1588.
+func (t AnnounceMsg) Validate() error {
+	n := t.MarshalLen()
+	if len(t.PathTraceTlv.pathSequence) == 0 {
+		// An empty PATH_TRACE TLV is not required
+		n -= 4
+	}
+
+	if err := t.Header.validate(AnnounceMsgType, n, true); err != nil {
+		return err
+	}
+
+	if t.StepsRemoved > maxStepsRemoved {
+		return &ValidationError{AnnounceMsgType, "stepsRemoved", t.StepsRemoved, ErrInvalidStepsRemoved}
+	}
+
+	// Only the grandmaster itself sends Announce messages with stepsRemoved 0
+	if (t.StepsRemoved == 0) != (t.GMIdentity == t.Header.ClockIdentity) {
+		return &ValidationError{AnnounceMsgType, "grandmasterIdentity", t.GMIdentity, ErrInvalidGMIdentity}
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -157,6 +158,54 @@ func TestUnmarshalAnnounce(t *testing.T) {
 				0x0, 0x0, 0x20,
 			}),
 		},
+		{
+			desc: "Power profile TLVs",
+			m: &AnnounceMsg{
+				Header: Header{
+					MessageType:      AnnounceMsgType,
+					MessageLength:    HeaderLen + AnnouncePayloadLen + 4 + PowerProfileTlvLen + 4 + PowerProfile2011TlvLen,
+					VersionPTP:       Version2,
+					ClockIdentity:    0x000af7fffe42a753,
+					PortNumber:       2,
+					SequenceID:       55330,
+					LogMessagePeriod: 0,
+				},
+				GMClockQuality: ClockQuality{
+					ClockClass:    PrimarySyncRefClass,
+					ClockAccuracy: ClockAccuracy100ns,
+					ClockVariance: 200,
+				},
+				CurrentUtcOffset: 36,
+				GMPriority1:      128,
+				GMPriority2:      128,
+				GMIdentity:       0x001d7ffffe80024a,
+				StepsRemoved:     0,
+				TimeSource:       TimeSourceGPS,
+				PowerProfile:     &PowerProfileTlv{GrandmasterID: 3, TotalTimeInaccuracy: 250},
+				PowerProfile2011: &PowerProfile2011Tlv{GrandmasterID: 3, GrandmasterTimeInaccuracy: 50, NetworkTimeInaccuracy: 200},
+			},
+			b: []byte{0xb, 0x2, 0x0, 0x6a, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				// ClockIdentity
+				0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x5, 0x0,
+				// Message body
+				// Reserved 10 bytes
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x24,
+				0x0, 0x80,
+				0x6, 0x21, 0x0, 0xc8, 0x80,
+				// GM Identity
+				0x0, 0x1d, 0x7f, 0xff, 0xfe, 0x80, 0x2, 0x4a,
+				0x0, 0x0, 0x20,
+				// IEEE C37.238-2017 TLV
+				0x0, 0x3, 0x0, 0x10, 0x1c, 0x12, 0x9d, 0x0, 0x0, 0x2,
+				0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xfa,
+				// IEEE C37.238-2011 TLV
+				0x0, 0x3, 0x0, 0x12, 0x1c, 0x12, 0x9d, 0x0, 0x0, 0x1,
+				0x0, 0x3, 0x0, 0x0, 0x0, 0x32, 0x0, 0x0, 0x0, 0xc8, 0x0, 0x0,
+			},
+		},
 		{
 			desc: "Invalid clock class",
 			b: append([]byte{0xb, 0x2, 0x0, 0x40, 0x0, 0x0, 0x0, 0x0,
@@ -168,7 +217,7 @@ func TestUnmarshalAnnounce(t *testing.T) {
 				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
 				0x0, 0x24,
 				0x0, 0x80,
-				// Wrong class - 0x8 instead of {6,7,248,255}
+				// Wrong class - 0x8 is reserved
 				0x8, 0x21, 0x0, 0xc8, 0x80,
 				// GM Identity
 				0x0, 0x1d, 0x7f, 0xff, 0xfe, 0x80, 0x2, 0x4a,
@@ -277,7 +326,7 @@ func TestUnmarshalAnnounce(t *testing.T) {
 			m := new(AnnounceMsg)
 			err := m.UnmarshalBinary(tt.b)
 			if err != nil {
-				if want, got := tt.err, err; want != got {
+				if want, got := tt.err, err; !errors.Is(got, want) {
 					t.Fatalf("unexpected error: %v != %v", want, got)
 				}
 
@@ -290,3 +339,59 @@ func TestUnmarshalAnnounce(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnounceRoundTrip(t *testing.T) {
+	header := Header{
+		MessageType:   AnnounceMsgType,
+		VersionPTP:    Version2,
+		ClockIdentity: 0x000af7fffe42a753,
+		PortNumber:    2,
+		SequenceID:    55330,
+	}
+	body := AnnounceMsg{
+		Header:           header,
+		GMClockQuality:   ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 200},
+		CurrentUtcOffset: 37,
+		GMPriority1:      128,
+		GMPriority2:      128,
+		GMIdentity:       0x001d7ffffe80024a,
+		TimeSource:       TimeSourceGPS,
+	}
+
+	var tests = []struct {
+		desc string
+		m    AnnounceMsg
+	}{
+		// MarshalBinary sends an empty PATH_TRACE TLV
+		{desc: "Empty path trace", m: body},
+		{desc: "Path trace", m: func() AnnounceMsg {
+			m := body
+			m.PathTraceTlv = PathTraceTlv{pathSequence: []uint64{0x000af7fffe42a753, 0x001d7ffffe80024a}}
+			return m
+		}()},
+		{desc: "Power profile", m: func() AnnounceMsg {
+			m := body
+			m.PowerProfile = &PowerProfileTlv{GrandmasterID: 3, TotalTimeInaccuracy: 50}
+			m.PowerProfile2011 = &PowerProfile2011Tlv{GrandmasterID: 3, GrandmasterTimeInaccuracy: 10, NetworkTimeInaccuracy: 40}
+			return m
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got AnnounceMsg
+			if err := got.UnmarshalBinary(b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tt.m, got) {
+				t.Fatalf("unexpected Announce:\n- want: %#v\n-  got: %#v", tt.m, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package ptp
+
+// ComparisonResult is the result of comparing data set A with data set B.
+type ComparisonResult int8
+
+const (
+	// DatasetWorse means A is worse than B
+	DatasetWorse ComparisonResult = -2
+	// DatasetWorseByTopology means A is worse than B by topology only, both
+	// describe the same grandmaster
+	DatasetWorseByTopology ComparisonResult = -1
+	// DatasetBetterByTopology means A is better than B by topology only, both
+	// describe the same grandmaster
+	DatasetBetterByTopology ComparisonResult = 1
+	// DatasetBetter means A is better than B
+	DatasetBetter ComparisonResult = 2
+)
+
+var comparisonResultNames = map[ComparisonResult]string{
+	DatasetWorse:            "worse",
+	DatasetWorseByTopology:  "worseByTopology",
+	DatasetBetterByTopology: "betterByTopology",
+	DatasetBetter:           "better",
+}
+
+// String returns the name of r.
+func (r ComparisonResult) String() string {
+	name, ok := comparisonResultNames[r]
+	return enumString(name, ok, uint64(uint8(r)))
+}
+
+// Better reports whether A is better than B, by topology or not.
+func (r ComparisonResult) Better() bool {
+	return r > 0
+}
+
+// ComparisonDataset holds the fields of an Announce message compared by the
+// best master clock algorithm, and the identity of the port receiving it.
+type ComparisonDataset struct {
+	GMPriority1    uint8
+	GMIdentity     uint64
+	GMClockQuality ClockQuality
+	GMPriority2    uint8
+	StepsRemoved   uint16
+	// Sender is the sourcePortIdentity of the Announce message
+	Sender PortIdentity
+	// Receiver is the identity of the port the message was received on
+	Receiver PortIdentity
+}
+
+// NewComparisonDataset returns the data set of m received on port receiver.
+func NewComparisonDataset(m *AnnounceMsg, receiver PortIdentity) ComparisonDataset {
+	return ComparisonDataset{
+		GMPriority1:    m.GMPriority1,
+		GMIdentity:     m.GMIdentity,
+		GMClockQuality: m.GMClockQuality,
+		GMPriority2:    m.GMPriority2,
+		StepsRemoved:   m.StepsRemoved,
+		Sender:         m.SourcePortIdentity(),
+		Receiver:       receiver,
+	}
+}
+
+// comparePortIdentity compares a and b as octet strings, returning -1, 0 or
+// +1.
+func comparePortIdentity(a, b PortIdentity) int {
+	switch {
+	case a.ClockIdentity < b.ClockIdentity:
+		return -1
+	case a.ClockIdentity > b.ClockIdentity:
+		return 1
+	case a.PortNumber < b.PortNumber:
+		return -1
+	case a.PortNumber > b.PortNumber:
+		return 1
+	}
+
+	return 0
+}
+
+// Compare compares a with b following the data set comparison algorithm of
+// IEEE 1588-2008 figures 27 and 28, unchanged as figures 34 and 35 of
+// IEEE 1588-2019. Lower values are better.
+//
+// ErrSelfAnnounce is returned if the data set with more stepsRemoved was
+// sent by its receiving port, and ErrDuplicateDataset if both describe the
+// same message received on the same port.
+func (a ComparisonDataset) Compare(b ComparisonDataset) (ComparisonResult, error) {
+	if a.GMIdentity == b.GMIdentity {
+		return a.compareTopology(b)
+	}
+
+	qa, qb := a.GMClockQuality, b.GMClockQuality
+	switch {
+	case a.GMPriority1 != b.GMPriority1:
+		return better(a.GMPriority1 < b.GMPriority1), nil
+	case qa.ClockClass != qb.ClockClass:
+		return better(qa.ClockClass < qb.ClockClass), nil
+	case qa.ClockAccuracy != qb.ClockAccuracy:
+		return better(qa.ClockAccuracy < qb.ClockAccuracy), nil
+	case qa.ClockVariance != qb.ClockVariance:
+		return better(qa.ClockVariance < qb.ClockVariance), nil
+	case a.GMPriority2 != b.GMPriority2:
+		return better(a.GMPriority2 < b.GMPriority2), nil
+	}
+
+	return better(a.GMIdentity < b.GMIdentity), nil
+}
+
+// compareTopology compares data sets of the same grandmaster, figure 28 of
+// IEEE 1588-2008.
+func (a ComparisonDataset) compareTopology(b ComparisonDataset) (ComparisonResult, error) {
+	switch sa, sb := int(a.StepsRemoved), int(b.StepsRemoved); {
+	case sa+1 < sb:
+		return DatasetBetter, nil
+	case sb+1 < sa:
+		return DatasetWorse, nil
+	case sa < sb:
+		switch comparePortIdentity(b.Receiver, b.Sender) {
+		case -1:
+			return DatasetBetter, nil
+		case 1:
+			return DatasetBetterByTopology, nil
+		}
+		return 0, ErrSelfAnnounce
+	case sa > sb:
+		switch comparePortIdentity(a.Receiver, a.Sender) {
+		case -1:
+			return DatasetWorse, nil
+		case 1:
+			return DatasetWorseByTopology, nil
+		}
+		return 0, ErrSelfAnnounce
+	}
+
+	switch comparePortIdentity(a.Sender, b.Sender) {
+	case -1:
+		return DatasetBetterByTopology, nil
+	case 1:
+		return DatasetWorseByTopology, nil
+	}
+
+	switch {
+	case a.Receiver.PortNumber < b.Receiver.PortNumber:
+		return DatasetBetterByTopology, nil
+	case a.Receiver.PortNumber > b.Receiver.PortNumber:
+		return DatasetWorseByTopology, nil
+	}
+
+	return 0, ErrDuplicateDataset
+}
+
+func better(ok bool) ComparisonResult {
+	if ok {
+		return DatasetBetter
+	}
+	return DatasetWorse
+}
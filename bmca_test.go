@@ -0,0 +1,229 @@
+package ptp
+
+import "testing"
+
+func TestComparisonDataset(t *testing.T) {
+	const (
+		gm1 uint64 = 0x000af7fffe000001
+		gm2 uint64 = 0x000af7fffe000002
+	)
+
+	port := func(id uint64, n uint16) PortIdentity {
+		return PortIdentity{ClockIdentity: id, PortNumber: n}
+	}
+
+	base := ComparisonDataset{
+		GMPriority1:    128,
+		GMIdentity:     gm1,
+		GMClockQuality: ClockQuality{ClockClass: 6, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 0x4e5d},
+		GMPriority2:    128,
+		StepsRemoved:   1,
+		Sender:         port(0x10, 1),
+		Receiver:       port(0x20, 1),
+	}
+
+	with := func(fn func(d *ComparisonDataset)) ComparisonDataset {
+		d := base
+		fn(&d)
+		return d
+	}
+
+	otherGM := func(fn func(d *ComparisonDataset)) ComparisonDataset {
+		return with(func(d *ComparisonDataset) {
+			d.GMIdentity = gm2
+			fn(d)
+		})
+	}
+
+	var tests = []struct {
+		desc string
+		a, b ComparisonDataset
+		want ComparisonResult
+		err  error
+	}{
+		{
+			desc: "Lower priority1",
+			a:    base,
+			b:    otherGM(func(d *ComparisonDataset) { d.GMPriority1 = 129 }),
+			want: DatasetBetter,
+		},
+		{
+			desc: "Priority1 before clockClass",
+			a:    with(func(d *ComparisonDataset) { d.GMPriority1 = 129 }),
+			b:    otherGM(func(d *ComparisonDataset) { d.GMClockQuality.ClockClass = 248 }),
+			want: DatasetWorse,
+		},
+		{
+			desc: "Higher clockClass",
+			a:    with(func(d *ComparisonDataset) { d.GMClockQuality.ClockClass = 7 }),
+			b:    otherGM(func(d *ComparisonDataset) {}),
+			want: DatasetWorse,
+		},
+		{
+			desc: "Lower clockAccuracy",
+			a:    with(func(d *ComparisonDataset) { d.GMClockQuality.ClockAccuracy = ClockAccuracy25ns }),
+			b:    otherGM(func(d *ComparisonDataset) {}),
+			want: DatasetBetter,
+		},
+		{
+			desc: "Higher offsetScaledLogVariance",
+			a:    with(func(d *ComparisonDataset) { d.GMClockQuality.ClockVariance = 0xffff }),
+			b:    otherGM(func(d *ComparisonDataset) {}),
+			want: DatasetWorse,
+		},
+		{
+			desc: "Lower priority2",
+			a:    with(func(d *ComparisonDataset) { d.GMPriority2 = 127 }),
+			b:    otherGM(func(d *ComparisonDataset) {}),
+			want: DatasetBetter,
+		},
+		{
+			desc: "Quality before stepsRemoved",
+			a:    with(func(d *ComparisonDataset) { d.StepsRemoved = 10 }),
+			b:    otherGM(func(d *ComparisonDataset) { d.GMPriority2 = 129 }),
+			want: DatasetBetter,
+		},
+		{
+			desc: "Lower grandmasterIdentity",
+			a:    base,
+			b:    otherGM(func(d *ComparisonDataset) {}),
+			want: DatasetBetter,
+		},
+		{
+			desc: "Higher grandmasterIdentity",
+			a:    otherGM(func(d *ComparisonDataset) {}),
+			b:    base,
+			want: DatasetWorse,
+		},
+		{
+			desc: "Same grandmaster, fewer stepsRemoved by more than 1",
+			a:    base,
+			b:    with(func(d *ComparisonDataset) { d.StepsRemoved = 3 }),
+			want: DatasetBetter,
+		},
+		{
+			desc: "Same grandmaster, more stepsRemoved by more than 1",
+			a:    with(func(d *ComparisonDataset) { d.StepsRemoved = 3 }),
+			b:    base,
+			want: DatasetWorse,
+		},
+		{
+			desc: "Same grandmaster, 1 step less, receiver of B lower than sender",
+			a:    base,
+			b:    with(func(d *ComparisonDataset) { d.StepsRemoved = 2; d.Sender = port(0x30, 1) }),
+			want: DatasetBetter,
+		},
+		{
+			desc: "Same grandmaster, 1 step less, receiver of B higher than sender",
+			a:    base,
+			b:    with(func(d *ComparisonDataset) { d.StepsRemoved = 2; d.Sender = port(0x20, 0) }),
+			want: DatasetBetterByTopology,
+		},
+		{
+			desc: "Same grandmaster, 1 step less, B sent by its receiver",
+			a:    base,
+			b:    with(func(d *ComparisonDataset) { d.StepsRemoved = 2; d.Sender = port(0x20, 1) }),
+			err:  ErrSelfAnnounce,
+		},
+		{
+			desc: "Same grandmaster, 1 step more, receiver of A lower than sender",
+			a:    with(func(d *ComparisonDataset) { d.StepsRemoved = 2; d.Sender = port(0x30, 1) }),
+			b:    base,
+			want: DatasetWorse,
+		},
+		{
+			desc: "Same grandmaster, 1 step more, receiver of A higher than sender",
+			a:    with(func(d *ComparisonDataset) { d.StepsRemoved = 2 }),
+			b:    base,
+			want: DatasetWorseByTopology,
+		},
+		{
+			desc: "Same grandmaster, 1 step more, A sent by its receiver",
+			a:    with(func(d *ComparisonDataset) { d.StepsRemoved = 2; d.Sender = d.Receiver }),
+			b:    base,
+			err:  ErrSelfAnnounce,
+		},
+		{
+			desc: "Same grandmaster and steps, lower sender clockIdentity",
+			a:    base,
+			b:    with(func(d *ComparisonDataset) { d.Sender = port(0x11, 0) }),
+			want: DatasetBetterByTopology,
+		},
+		{
+			desc: "Same grandmaster and steps, higher sender portNumber",
+			a:    with(func(d *ComparisonDataset) { d.Sender = port(0x10, 2) }),
+			b:    base,
+			want: DatasetWorseByTopology,
+		},
+		{
+			desc: "Same sender, lower receiver portNumber",
+			a:    base,
+			b:    with(func(d *ComparisonDataset) { d.Receiver = port(0x20, 2) }),
+			want: DatasetBetterByTopology,
+		},
+		{
+			desc: "Same sender, higher receiver portNumber",
+			a:    with(func(d *ComparisonDataset) { d.Receiver = port(0x20, 3) }),
+			b:    base,
+			want: DatasetWorseByTopology,
+		},
+		{
+			desc: "Same message on the same port",
+			a:    base,
+			b:    base,
+			err:  ErrDuplicateDataset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := tt.a.Compare(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+
+			if want := tt.want; want != got {
+				t.Fatalf("unexpected result: %v != %v", want, got)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Comparing the other way round gives the opposite result
+			got, err = tt.b.Compare(tt.a)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want := -tt.want; want != got {
+				t.Fatalf("unexpected reverse result: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestNewComparisonDataset(t *testing.T) {
+	m := &AnnounceMsg{
+		Header:         Header{ClockIdentity: 0x10, PortNumber: 1},
+		GMPriority1:    128,
+		GMClockQuality: ClockQuality{ClockClass: 6},
+		GMPriority2:    127,
+		GMIdentity:     0x30,
+		StepsRemoved:   2,
+	}
+
+	want := ComparisonDataset{
+		GMPriority1:    128,
+		GMIdentity:     0x30,
+		GMClockQuality: ClockQuality{ClockClass: 6},
+		GMPriority2:    127,
+		StepsRemoved:   2,
+		Sender:         PortIdentity{ClockIdentity: 0x10, PortNumber: 1},
+		Receiver:       PortIdentity{ClockIdentity: 0x20, PortNumber: 3},
+	}
+
+	if got := NewComparisonDataset(m, PortIdentity{ClockIdentity: 0x20, PortNumber: 3}); want != got {
+		t.Fatalf("unexpected data set:\n- want: %+v\n-  got: %+v", want, got)
+	}
+}
@@ -0,0 +1,452 @@
+package ptp
+
+import "time"
+
+// BoundaryPort is a port of a Boundary. Its fields may be configured
+// before the clock is started.
+type BoundaryPort struct {
+	Builder      *Builder
+	StateMachine *PortStateMachine
+	// Master sends the messages of the port in state MASTER
+	Master *MasterPort
+	// Slave measures the offset from the parent of the port in states
+	// UNCALIBRATED and SLAVE
+	Slave *E2ESlave
+}
+
+// PortMessage is a message to send on a port.
+type PortMessage struct {
+	Port    uint16
+	Message Message
+}
+
+// Boundary is a boundary clock of IEEE 1588-2008 clause 9: its ports
+// share defaultDS and a clock. The state decision algorithm runs across all
+// ports, the port receiving the best master becomes SLAVE and disciplines
+// the clock with the servo, and the ports in state MASTER send the time of
+// the clock. Announce and Sync messages are never forwarded: the Announce
+// messages of the master ports are regenerated from parentDS, with
+// stepsRemoved incremented.
+//
+// Only the delay request-response mechanism is supported. Timers run on
+// Clock, see TimerClock, and timestamps are taken from Clock, by the caller
+// for ingress and egress timestamps. BoundaryClock is the ClockType of such
+// clocks.
+//
+// A Boundary is not safe for concurrent use.
+type Boundary struct {
+	DefaultDS DefaultDataSetTlv
+	// LocalTimeProperties is timePropertiesDS of the clock when it is the
+	// grandmaster
+	LocalTimeProperties TimePropertiesDataSetTlv
+	Clock               Clock
+	Servo               Servo
+
+	parent         ParentDataSetTlv
+	stepsRemoved   uint16
+	timeProperties TimePropertiesDataSetTlv
+	servoState     ServoState
+	// lastSync is the receipt time of the last Sync fed to Servo
+	lastSync time.Time
+
+	ports   []*BoundaryPort
+	foreign *ForeignMasterTable
+}
+
+// NewBoundary returns a Boundary of defaultDS.NumberPorts ports
+// disciplining clock with servo. The ports are numbered from 1 and start in
+// state INITIALIZING.
+func NewBoundary(defaultDS DefaultDataSetTlv, clock Clock, servo Servo) *Boundary {
+	c := &Boundary{
+		DefaultDS: defaultDS,
+		Clock:     clock,
+		Servo:     servo,
+	}
+	c.parent = c.localParent()
+
+	// The timers follow Clock, which may be set after NewBoundary
+	timers := boundaryTimers{c}
+	c.foreign = NewForeignMasterTable(defaultDS.ClockIdentity, timers)
+
+	for i := uint16(1); i <= defaultDS.NumberPorts; i++ {
+		b := NewBuilder(PortIdentity{ClockIdentity: defaultDS.ClockIdentity, PortNumber: i}, defaultDS.DomainNumber)
+		c.ports = append(c.ports, &BoundaryPort{
+			Builder:      b,
+			StateMachine: NewPortStateMachine(b.LogAnnounceInterval, timers),
+			Master:       NewMasterPort(b, defaultDS, clock),
+			Slave:        NewE2ESlave(b, PortIdentity{}),
+		})
+	}
+
+	return c
+}
+
+// boundaryTimers is the Clock of the timers of a Boundary, the current Clock
+// of the Boundary.
+type boundaryTimers struct {
+	c *Boundary
+}
+
+func (t boundaryTimers) Now() (time.Time, error)           { return t.c.Clock.Now() }
+func (t boundaryTimers) AdjustFrequency(ppb float64) error { return t.c.Clock.AdjustFrequency(ppb) }
+func (t boundaryTimers) Step(d time.Duration) error        { return t.c.Clock.Step(d) }
+func (t boundaryTimers) MaxAdjustment() float64            { return t.c.Clock.MaxAdjustment() }
+func (t boundaryTimers) TimerNow() time.Time               { return timerNow(t.c.Clock) }
+
+// localParent returns parentDS of the clock when it is the grandmaster.
+func (c *Boundary) localParent() ParentDataSetTlv {
+	return ParentDataSetTlv{
+		ClockIdentity:           c.DefaultDS.ClockIdentity,
+		GrandmasterPriority1:    c.DefaultDS.Priority1,
+		GrandmasterClockQuality: c.DefaultDS.ClockQuality,
+		GrandmasterPriority2:    c.DefaultDS.Priority2,
+		GrandmasterIdentity:     c.DefaultDS.ClockIdentity,
+	}
+}
+
+// Port returns port number n, nil if there is none.
+func (c *Boundary) Port(n uint16) *BoundaryPort {
+	if n < 1 || int(n) > len(c.ports) {
+		return nil
+	}
+	return c.ports[n-1]
+}
+
+// port returns port number n, or ErrUnknownPort.
+func (c *Boundary) port(n uint16) (*BoundaryPort, error) {
+	p := c.Port(n)
+	if p == nil {
+		return nil, ErrUnknownPort
+	}
+	return p, nil
+}
+
+// Parent returns parentDS.
+func (c *Boundary) Parent() ParentDataSetTlv {
+	return c.parent
+}
+
+// StepsRemoved returns currentDS.stepsRemoved.
+func (c *Boundary) StepsRemoved() uint16 {
+	return c.stepsRemoved
+}
+
+// TimeProperties returns timePropertiesDS.
+func (c *Boundary) TimeProperties() TimePropertiesDataSetTlv {
+	if c.stepsRemoved == 0 {
+		return c.LocalTimeProperties
+	}
+	return c.timeProperties
+}
+
+// ServoState returns the state of the servo after the last measurement.
+func (c *Boundary) ServoState() ServoState {
+	return c.servoState
+}
+
+// Start completes the initialization of the ports, which start listening.
+// The timers of a port run on the LogAnnounceInterval of its Builder.
+func (c *Boundary) Start() {
+	for _, p := range c.ports {
+		p.StateMachine.LogAnnounceInterval = p.Builder.LogAnnounceInterval
+		p.StateMachine.Handle(EventInitializeComplete)
+	}
+}
+
+// Poll expires the timers of the ports, runs the state decision algorithm
+// and returns the messages due on the ports in state MASTER.
+func (c *Boundary) Poll() ([]PortMessage, error) {
+	for _, p := range c.ports {
+		before := p.StateMachine.State()
+		after := p.StateMachine.Poll()
+
+		// The foreign masters of a port are dropped on announce
+		// receipt timeout
+		if before != after && (after == Master || after == Listening) {
+			c.foreign.Clear(p.Builder.PortIdentity)
+		}
+	}
+
+	c.Decide()
+
+	var msgs []PortMessage
+	for _, p := range c.ports {
+		if p.StateMachine.State() != Master {
+			continue
+		}
+
+		c.updateMaster(p.Master)
+		pm, err := p.Master.Poll()
+		for _, m := range pm {
+			msgs = append(msgs, PortMessage{Port: p.Builder.PortIdentity.PortNumber, Message: m})
+		}
+		if err != nil {
+			return msgs, err
+		}
+	}
+
+	return msgs, nil
+}
+
+// Deadline returns the time Poll is due next, the earliest deadline of the
+// timers of the ports and of the messages of the master ports.
+func (c *Boundary) Deadline() time.Time {
+	var deadline time.Time
+	for _, p := range c.ports {
+		d := p.StateMachine.Deadline()
+		if p.StateMachine.State() == Master {
+			d = p.Master.Deadline()
+		}
+
+		if !d.IsZero() && (deadline.IsZero() || d.Before(deadline)) {
+			deadline = d
+		}
+	}
+
+	return deadline
+}
+
+// updateMaster copies the data sets of the clock to m.
+func (c *Boundary) updateMaster(m *MasterPort) {
+	m.Clock = c.Clock
+	m.DefaultDS = c.DefaultDS
+	m.TimeProperties = c.TimeProperties()
+	m.StepsRemoved = c.stepsRemoved
+
+	m.Parent = nil
+	if c.stepsRemoved > 0 {
+		parent := c.parent
+		m.Parent = &parent
+	}
+}
+
+// Decide runs the state decision algorithm across the ports and updates the
+// data sets of the clock with the decisions, IEEE 1588-2008 9.3.5.
+func (c *Boundary) Decide() {
+	states := make([]PortState, len(c.ports))
+	erbest := make([]*ComparisonDataset, len(c.ports))
+	for i, p := range c.ports {
+		states[i] = p.StateMachine.State()
+		erbest[i] = c.foreign.Erbest(p.Builder.PortIdentity)
+	}
+
+	decisions := DecideStates(c.DefaultDS, states, erbest)
+
+	// The data sets of the clock are updated by S1, M1 and M2 decisions
+	slave, local := -1, false
+	for i, d := range decisions {
+		switch d.Code {
+		case DecisionS1:
+			slave = i
+		case DecisionM1, DecisionM2:
+			local = true
+		}
+	}
+
+	if slave >= 0 {
+		c.selectMaster(c.ports[slave], erbest[slave])
+	} else if local {
+		c.parent = c.localParent()
+		c.stepsRemoved = 0
+	}
+
+	for i, p := range c.ports {
+		p.StateMachine.StepsRemoved = c.stepsRemoved
+		p.StateMachine.Decide(decisions[i])
+	}
+}
+
+// selectMaster makes the foreign master of ebest, received on port p, the
+// parent of the clock.
+func (c *Boundary) selectMaster(p *BoundaryPort, ebest *ComparisonDataset) {
+	a, ok := c.foreign.Announce(ebest.Receiver, ebest.Sender)
+	if !ok {
+		return
+	}
+
+	c.parent = ParentDataSetTlv{
+		ClockIdentity:           ebest.Sender.ClockIdentity,
+		PortNumber:              ebest.Sender.PortNumber,
+		GrandmasterPriority1:    a.GMPriority1,
+		GrandmasterClockQuality: a.GMClockQuality,
+		GrandmasterPriority2:    a.GMPriority2,
+		GrandmasterIdentity:     a.GMIdentity,
+	}
+	c.stepsRemoved = a.StepsRemoved + 1
+	c.timeProperties = TimePropertiesDataSetTlv{
+		CurrentUtcOffset: uint16(a.CurrentUtcOffset),
+		LI61:             a.LI61,
+		LI59:             a.LI59,
+		UTCV:             a.UtcReasonable,
+		PTP:              a.TimeScale,
+		TTRA:             a.TimeTraceable,
+		FTRA:             a.FrequencyTraceable,
+		TimeSource:       a.TimeSource,
+	}
+
+	if p.Slave.Master() == ebest.Sender {
+		return
+	}
+
+	// Change of master
+	p.Slave.SetMaster(ebest.Sender)
+	p.StateMachine.Handle(EventSynchronizationFault)
+	c.Servo.Reset()
+	c.servoState = ServoUnlocked
+	c.lastSync = time.Time{}
+}
+
+// HandleAnnounce records the Announce message m received on port n. The
+// announce receipt timer of the port restarts once its sender is qualified.
+func (c *Boundary) HandleAnnounce(n uint16, m *AnnounceMsg) error {
+	p, err := c.port(n)
+	if err != nil {
+		return err
+	}
+
+	switch p.StateMachine.State() {
+	case Initializing, Faulty, Disabled:
+		return nil
+	}
+
+	if c.foreign.Add(m, p.Builder.PortIdentity) {
+		p.StateMachine.AnnounceReceived()
+	}
+
+	return nil
+}
+
+// HandleSync records the Sync message m received on port n at t2, a time of
+// Clock. Sync messages are only used by the port synchronized to the parent,
+// which disciplines Clock.
+func (c *Boundary) HandleSync(n uint16, m *SyncMsg, t2 time.Time) error {
+	p, err := c.slave(n)
+	if p == nil {
+		return err
+	}
+
+	if e, ok := p.Slave.HandleSync(m, t2); ok {
+		return c.discipline(p, e)
+	}
+
+	return nil
+}
+
+// HandleFollowUp records the Follow_Up message m received on port n.
+func (c *Boundary) HandleFollowUp(n uint16, m *FollowUpMsg) error {
+	p, err := c.slave(n)
+	if p == nil {
+		return err
+	}
+
+	if e, ok := p.Slave.HandleFollowUp(m); ok {
+		return c.discipline(p, e)
+	}
+
+	return nil
+}
+
+// DelayReq returns the next Delay_Req message of port n, nil if the port is
+// not synchronized to the parent.
+func (c *Boundary) DelayReq(n uint16) *DelReqMsg {
+	p, _ := c.slave(n)
+	if p == nil {
+		return nil
+	}
+
+	return p.Slave.DelayReq()
+}
+
+// DelayReqSent records the transmission time t3 of the Delay_Req message m
+// of port n.
+func (c *Boundary) DelayReqSent(n uint16, m *DelReqMsg, t3 time.Time) error {
+	p, err := c.slave(n)
+	if p == nil {
+		return err
+	}
+
+	p.Slave.DelayReqSent(m, t3)
+
+	return nil
+}
+
+// HandleDelayResp records the Delay_Resp message m received on port n. The
+// first meanPathDelay measured completes the offset of the last Sync, which
+// then disciplines Clock.
+func (c *Boundary) HandleDelayResp(n uint16, m *DelRespMsg) error {
+	p, err := c.slave(n)
+	if p == nil {
+		return err
+	}
+
+	if e, ok := p.Slave.HandleDelayResp(m); ok {
+		return c.discipline(p, e)
+	}
+
+	return nil
+}
+
+// slave returns port n if it is synchronized to the parent, in state
+// UNCALIBRATED or SLAVE.
+func (c *Boundary) slave(n uint16) (*BoundaryPort, error) {
+	p, err := c.port(n)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.StateMachine.State() {
+	case Uncalibrated, Slave:
+		return p, nil
+	}
+
+	return nil, nil
+}
+
+// discipline applies the measurement e of port p to Clock. Offsets are not
+// known before meanPathDelay is measured, and each Sync is fed to the servo
+// once. The port becomes SLAVE once the servo is locked.
+func (c *Boundary) discipline(p *BoundaryPort, e E2EMeasurement) error {
+	if !e.DelayMeasured || e.SyncReceived.Equal(c.lastSync) {
+		return nil
+	}
+	c.lastSync = e.SyncReceived
+
+	state, err := Discipline(c.Clock, c.Servo, e.OffsetFromMaster, e.SyncReceived)
+	c.servoState = state
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case ServoLocked, ServoLockedStable:
+		p.StateMachine.Handle(EventMasterClockSelected)
+	}
+
+	return nil
+}
+
+// SyncSent records the egress timestamp of the Sync message m sent on port n
+// and returns its Follow_Up, nil for a one-step Sync.
+func (c *Boundary) SyncSent(n uint16, m *SyncMsg, egress time.Time) (*FollowUpMsg, error) {
+	p, err := c.port(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Master.SyncSent(m, egress), nil
+}
+
+// HandleDelayReq returns the Delay_Resp answering the Delay_Req message m
+// received on port n at ingress, nil if the port is not in state MASTER.
+func (c *Boundary) HandleDelayReq(n uint16, m *DelReqMsg, ingress time.Time) (*DelRespMsg, error) {
+	p, err := c.port(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.StateMachine.State() != Master {
+		return nil, nil
+	}
+
+	return p.Master.HandleDelayReq(m, ingress), nil
+}
@@ -0,0 +1,251 @@
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBoundary(t *testing.T) {
+	const clockIdentity = 0x000af7fffe000001
+
+	now := time.Unix(1000, 0)
+	clock := NewSimClock(now, 0, 0, 1)
+	advance := func(d time.Duration) {
+		now = now.Add(d)
+		clock.Advance(d)
+	}
+
+	defaultDS := DefaultDataSetTlv{
+		NumberPorts:   4,
+		Priority1:     128,
+		ClockQuality:  ClockQuality{ClockClass: 248, ClockAccuracy: ClockAccuracyNotSupported, ClockVariance: VarianceUnknown},
+		Priority2:     128,
+		ClockIdentity: clockIdentity,
+	}
+	bc := NewBoundary(defaultDS, clock, NewPIServo(0))
+	bc.Start()
+
+	// states checks the states of the ports
+	states := func(want ...PortState) {
+		t.Helper()
+		for i, want := range want {
+			if got := bc.Port(uint16(i + 1)).StateMachine.State(); want != got {
+				t.Fatalf("unexpected state of port %d: %v != %v", i+1, want, got)
+			}
+		}
+	}
+
+	// announces checks the messages sent by the ports and returns the
+	// Announce messages
+	announces := func(msgs []PortMessage) map[uint16]*AnnounceMsg {
+		t.Helper()
+		m := make(map[uint16]*AnnounceMsg)
+		for _, msg := range msgs {
+			var h *Header
+			switch pm := msg.Message.(type) {
+			case *AnnounceMsg:
+				m[msg.Port] = pm
+				h = &pm.Header
+			case *SyncMsg:
+				h = &pm.Header
+			default:
+				t.Fatalf("unexpected message: %v", pm)
+			}
+
+			if want, got := (PortIdentity{clockIdentity, msg.Port}), h.SourcePortIdentity(); want != got {
+				t.Fatalf("unexpected sourcePortIdentity: %v != %v", want, got)
+			}
+		}
+		return m
+	}
+
+	// poll returns the messages of the ports
+	poll := func() []PortMessage {
+		t.Helper()
+		msgs, err := bc.Poll()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return msgs
+	}
+
+	if msgs := poll(); len(msgs) != 0 {
+		t.Fatalf("unexpected messages while listening: %v", msgs)
+	}
+	states(Listening, Listening, Listening, Listening)
+
+	// No foreign master, the clock is the grandmaster
+	advance(3 * time.Second)
+	msgs := announces(poll())
+	states(Master, Master, Master, Master)
+
+	for port := uint16(1); port <= 4; port++ {
+		a := msgs[port]
+		if a == nil {
+			t.Fatalf("expected Announce on port %d", port)
+		}
+		if a.GMIdentity != clockIdentity || a.StepsRemoved != 0 {
+			t.Fatalf("unexpected Announce: %v", a)
+		}
+	}
+
+	// A better grandmaster on port 1
+	gmID := PortIdentity{ClockIdentity: 0x000af7fffe0000aa, PortNumber: 1}
+	gm := NewBuilder(gmID, 0)
+	announce := func() *AnnounceMsg {
+		return gm.Announce(AnnounceMsg{
+			Header:           Header{Flags: Flags{UtcReasonable: true, TimeScale: true}},
+			CurrentUtcOffset: 37,
+			GMPriority1:      100,
+			GMClockQuality:   ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy25ns},
+			GMPriority2:      128,
+			GMIdentity:       gmID.ClockIdentity,
+			TimeSource:       TimeSourceGPS,
+		})
+	}
+
+	for i := 0; i < ForeignMasterThreshold; i++ {
+		if err := bc.HandleAnnounce(1, announce()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	advance(time.Second)
+	msgs = announces(poll())
+	states(Uncalibrated, Master, Master, Master)
+
+	if want, got := uint16(1), bc.StepsRemoved(); want != got {
+		t.Fatalf("unexpected stepsRemoved: %v != %v", want, got)
+	}
+	if p := bc.Parent(); p.ClockIdentity != gmID.ClockIdentity || p.PortNumber != gmID.PortNumber || p.GrandmasterIdentity != gmID.ClockIdentity {
+		t.Fatalf("unexpected parentDS: %+v", p)
+	}
+	if tp := bc.TimeProperties(); tp.CurrentUtcOffset != 37 || !tp.UTCV || !tp.PTP || tp.TimeSource != TimeSourceGPS {
+		t.Fatalf("unexpected timePropertiesDS: %+v", tp)
+	}
+
+	// The master ports announce the grandmaster, one step further
+	if _, ok := msgs[1]; ok {
+		t.Fatal("unexpected Announce on the slave port")
+	}
+	for port := uint16(2); port <= 4; port++ {
+		a := msgs[port]
+		if a == nil {
+			t.Fatalf("expected Announce on port %d", port)
+		}
+		if a.GMIdentity != gmID.ClockIdentity || a.GMPriority1 != 100 || a.StepsRemoved != 1 || a.CurrentUtcOffset != 37 || !a.UtcReasonable {
+			t.Fatalf("unexpected Announce: %v", a)
+		}
+	}
+
+	// Sync messages of the grandmaster discipline the clock and are not
+	// forwarded
+	sync := func(port uint16) error {
+		t2, _ := clock.Now()
+		return bc.HandleSync(port, gm.Sync(clock.Reference()), t2)
+	}
+
+	// servo checks the servo state and the state of the slave port
+	servo := func(want ServoState, port PortState) {
+		t.Helper()
+		if got := bc.ServoState(); want != got {
+			t.Fatalf("unexpected servo state: %v != %v", want, got)
+		}
+		states(port)
+	}
+
+	// delay runs a delay request-response exchange on port 1
+	delay := func() error {
+		req := bc.DelayReq(1)
+		if req == nil {
+			t.Fatal("expected Delay_Req on the slave port")
+		}
+		t3, _ := clock.Now()
+		if err := bc.DelayReqSent(1, req, t3); err != nil {
+			return err
+		}
+		return bc.HandleDelayResp(1, gm.DelayResp(req, clock.Reference()))
+	}
+
+	// The offset is unknown until meanPathDelay is measured
+	if err := sync(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	servo(ServoUnlocked, Uncalibrated)
+
+	// The first Delay_Resp completes the offset of the last Sync
+	if err := delay(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	servo(ServoUnlocked, Uncalibrated)
+
+	// The Sync already fed to the servo is not fed again
+	if err := delay(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	servo(ServoUnlocked, Uncalibrated)
+
+	advance(time.Second)
+	if err := sync(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	servo(ServoLocked, Slave)
+	states(Slave, Master, Master, Master)
+
+	for _, msg := range poll() {
+		if msg.Port == 1 {
+			t.Fatalf("unexpected message on the slave port: %v", msg)
+		}
+	}
+
+	// Ports other than the slave port ignore Sync messages
+	if err := sync(2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sync(9); err != ErrUnknownPort {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Delay request-response of the slave and master ports
+	if bc.DelayReq(1) == nil {
+		t.Fatal("expected Delay_Req on the slave port")
+	}
+	if bc.DelayReq(2) != nil {
+		t.Fatal("unexpected Delay_Req on a master port")
+	}
+
+	downstream := NewBuilder(PortIdentity{ClockIdentity: 0x000af7fffe000002, PortNumber: 1}, 0)
+	req := downstream.DelayReq(time.Unix(0, 0))
+	if resp, err := bc.HandleDelayReq(2, req, now); err != nil || resp == nil || resp.RequestingPortIdentity != downstream.PortIdentity.ClockIdentity {
+		t.Fatalf("unexpected Delay_Resp: %v, %v", resp, err)
+	}
+	if resp, err := bc.HandleDelayReq(1, req, now); err != nil || resp != nil {
+		t.Fatalf("unexpected Delay_Resp on the slave port: %v, %v", resp, err)
+	}
+
+	// The grandmaster is lost, the clock becomes the grandmaster again
+	advance(3 * time.Second)
+	poll()
+	states(Master, Master, Master, Master)
+
+	if want, got := uint16(0), bc.StepsRemoved(); want != got {
+		t.Fatalf("unexpected stepsRemoved: %v != %v", want, got)
+	}
+	if want, got := uint64(clockIdentity), bc.Parent().GrandmasterIdentity; want != got {
+		t.Fatalf("unexpected grandmasterIdentity: %x != %x", want, got)
+	}
+}
+
+func TestBoundaryAnnounceInterval(t *testing.T) {
+	now := time.Unix(1000, 0)
+	defaultDS := DefaultDataSetTlv{NumberPorts: 1, ClockIdentity: 0x000af7fffe000001}
+	bc := NewBoundary(defaultDS, NewSimClock(now, 0, 0, 1), NewPIServo(0))
+
+	// The interval configured before Start runs the announce receipt timer
+	bc.Port(1).Builder.LogAnnounceInterval = 2
+	bc.Start()
+
+	if want, got := now.Add(3*4*time.Second), bc.Deadline(); !want.Equal(got) {
+		t.Fatalf("unexpected deadline: %v != %v", want, got)
+	}
+}
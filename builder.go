@@ -0,0 +1,188 @@
+package ptp
+
+import "time"
+
+// Builder builds the messages sent by a port. It fills in the header, so the
+// messages pass Validate: messageType, messageLength, domainNumber,
+// sourcePortIdentity, logMessageInterval and sequenceId, which is taken from
+// a separate counter for each message type. Responses take the sequenceId of
+// the message they respond to.
+//
+// A Builder is not safe for concurrent use.
+type Builder struct {
+	PortIdentity PortIdentity
+	DomainNumber uint8
+
+	// TwoStep sets twoStepFlag of Sync and Pdelay_Resp messages.
+	TwoStep bool
+
+	// Log intervals carried in logMessageInterval
+	LogAnnounceInterval     LogInterval
+	LogSyncInterval         LogInterval
+	LogMinDelayReqInterval  LogInterval
+	LogMinPdelayReqInterval LogInterval
+
+	sequenceIDs [16]uint16
+}
+
+// NewBuilder returns a Builder of messages sent from port id in domain.
+func NewBuilder(id PortIdentity, domain uint8) *Builder {
+	return &Builder{
+		PortIdentity: id,
+		DomainNumber: domain,
+	}
+}
+
+// SequenceID returns the sequenceId the next message of type msgType gets.
+func (b *Builder) SequenceID(msgType MsgType) uint16 {
+	return b.sequenceIDs[msgType&0xf]
+}
+
+// header returns the header of the next message of type msgType.
+func (b *Builder) header(msgType MsgType, logInterval LogInterval) Header {
+	seq := b.sequenceIDs[msgType&0xf]
+	b.sequenceIDs[msgType&0xf]++
+
+	return b.response(msgType, logInterval, seq)
+}
+
+// response returns the header of a message with sequenceId seq.
+func (b *Builder) response(msgType MsgType, logInterval LogInterval, seq uint16) Header {
+	return Header{
+		MessageType:      msgType,
+		VersionPTP:       Version2,
+		DomainNumber:     b.DomainNumber,
+		ClockIdentity:    b.PortIdentity.ClockIdentity,
+		PortNumber:       b.PortIdentity.PortNumber,
+		SequenceID:       seq,
+		LogMessagePeriod: logInterval,
+	}
+}
+
+// Sync returns the next Sync message.
+func (b *Builder) Sync(originTimestamp time.Time) *SyncMsg {
+	m := &SyncMsg{
+		Header:          b.header(SyncMsgType, b.LogSyncInterval),
+		OriginTimestamp: originTimestamp,
+	}
+	m.TwoSteps = b.TwoStep
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// FollowUp returns the Follow_Up message of sync.
+func (b *Builder) FollowUp(sync *SyncMsg, preciseOriginTimestamp time.Time) *FollowUpMsg {
+	m := &FollowUpMsg{
+		Header:                 b.response(FollowUpMsgType, b.LogSyncInterval, sync.SequenceID),
+		PreciseOriginTimestamp: preciseOriginTimestamp,
+	}
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// DelayReq returns the next Delay_Req message.
+func (b *Builder) DelayReq(originTimestamp time.Time) *DelReqMsg {
+	m := &DelReqMsg{
+		Header:          b.header(DelayReqMsgType, noLogMessageInterval),
+		OriginTimestamp: originTimestamp,
+	}
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// DelayResp returns the Delay_Resp message answering req received at
+// receiveTimestamp. The correctionField of req is copied.
+func (b *Builder) DelayResp(req *DelReqMsg, receiveTimestamp time.Time) *DelRespMsg {
+	m := &DelRespMsg{
+		Header:                 b.response(DelayRespMsgType, b.LogMinDelayReqInterval, req.SequenceID),
+		ReceiveTimestamp:       receiveTimestamp,
+		RequestingPortIdentity: req.ClockIdentity,
+		RequestingPortID:       req.PortNumber,
+	}
+	m.CorrectionNs = req.CorrectionNs
+	m.CorrectionSubNs = req.CorrectionSubNs
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// PDelayReq returns the next Pdelay_Req message.
+func (b *Builder) PDelayReq() *PDelReqMsg {
+	m := &PDelReqMsg{
+		Header: b.header(PDelayReqMsgType, b.LogMinPdelayReqInterval),
+	}
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// PDelayResp returns the Pdelay_Resp message answering req received at
+// requestReceiptTimestamp.
+func (b *Builder) PDelayResp(req *PDelReqMsg, requestReceiptTimestamp time.Time) *PDelRespMsg {
+	m := &PDelRespMsg{
+		Header:           b.response(PDelayRespMsgType, noLogMessageInterval, req.SequenceID),
+		ReceiveTimestamp: requestReceiptTimestamp,
+		ClockIdentity:    req.ClockIdentity,
+		PortNumber:       req.PortNumber,
+	}
+	m.TwoSteps = b.TwoStep
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// PDelayRespFollowUp returns the Pdelay_Resp_Follow_Up message of the
+// Pdelay_Resp answering req, sent at responseOriginTimestamp.
+func (b *Builder) PDelayRespFollowUp(req *PDelReqMsg, responseOriginTimestamp time.Time) *PDelRespFollowUpMsg {
+	m := &PDelRespFollowUpMsg{
+		Header:          b.response(PDelayRespFollowUpMsgType, noLogMessageInterval, req.SequenceID),
+		OriginTimestamp: responseOriginTimestamp,
+		ClockIdentity:   req.ClockIdentity,
+		PortNumber:      req.PortNumber,
+	}
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// Announce returns the next Announce message carrying the flagField, body
+// fields and TLVs of body, such as the leap second and traceability flags of
+// timePropertiesDS. If stepsRemoved of body is 0, the clock of the port is
+// the grandmaster and grandmasterIdentity is set to its clockIdentity.
+func (b *Builder) Announce(body AnnounceMsg) *AnnounceMsg {
+	m := body
+	m.Header = b.header(AnnounceMsgType, b.LogAnnounceInterval)
+	m.Flags = body.Flags
+	if m.StepsRemoved == 0 {
+		m.GMIdentity = b.PortIdentity.ClockIdentity
+	}
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return &m
+}
+
+// Signaling returns the next Signaling message to target carrying tlv.
+func (b *Builder) Signaling(target PortIdentity, tlv IntervalRequestTlv) *SignalingMsg {
+	m := &SignalingMsg{
+		Header:             b.header(SignalingMsgType, noLogMessageInterval),
+		ClockIdentity:      target.ClockIdentity,
+		PortNumber:         target.PortNumber,
+		IntervalRequestTlv: tlv,
+	}
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return m
+}
+
+// Management returns the next Management message carrying the target port,
+// boundary hops, action and TLVs of body.
+func (b *Builder) Management(body MgmtMsg) *MgmtMsg {
+	m := body
+	m.Header = b.header(MgmtMsgType, noLogMessageInterval)
+	m.MessageLength = uint16(m.MarshalLen())
+
+	return &m
+}
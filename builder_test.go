@@ -0,0 +1,121 @@
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	id := PortIdentity{ClockIdentity: 0x000af7fffe42a753, PortNumber: 1}
+	peer := PortIdentity{ClockIdentity: 0x000af7fffe42a754, PortNumber: 2}
+	now := time.Unix(500, 200)
+
+	b := NewBuilder(id, 24)
+	b.TwoStep = true
+	b.LogSyncInterval = -3
+
+	peerBuilder := NewBuilder(peer, 24)
+	delReq := peerBuilder.DelayReq(now)
+	delReq.CorrectionNs = 10
+	pdelReq := peerBuilder.PDelayReq()
+
+	sync := b.Sync(now)
+	b.Sync(now)
+
+	var tests = []struct {
+		desc string
+		m    Message
+		seq  uint16
+	}{
+		{desc: "Sync", m: b.Sync(now), seq: 2},
+		{desc: "Follow_Up", m: b.FollowUp(sync, now), seq: 0},
+		{desc: "Delay_Req", m: b.DelayReq(now), seq: 0},
+		{desc: "Delay_Req from peer", m: peerBuilder.DelayReq(now), seq: 1},
+		{desc: "Delay_Resp", m: b.DelayResp(delReq, now), seq: 0},
+		{desc: "Pdelay_Req", m: b.PDelayReq(), seq: 0},
+		{desc: "Pdelay_Resp", m: b.PDelayResp(pdelReq, now), seq: 0},
+		{desc: "Pdelay_Resp_Follow_Up", m: b.PDelayRespFollowUp(pdelReq, now), seq: 0},
+		{desc: "Announce", m: b.Announce(AnnounceMsg{
+			GMClockQuality: ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracyNotSupported},
+			TimeSource:     TimeSourceInternalOsc,
+			PowerProfile:   &PowerProfileTlv{GrandmasterID: 1},
+		}), seq: 0},
+		{desc: "Announce of boundary clock", m: b.Announce(AnnounceMsg{
+			GMClockQuality: ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy100ns},
+			GMIdentity:     peer.ClockIdentity,
+			StepsRemoved:   1,
+			TimeSource:     TimeSourceGPS,
+			PathTraceTlv:   PathTraceTlv{pathSequence: []uint64{peer.ClockIdentity}},
+		}), seq: 1},
+		{desc: "Signaling", m: b.Signaling(peer, IntervalRequestTlv{TimeSyncInterval: -3}), seq: 0},
+		{desc: "Management", m: b.Management(MgmtMsg{
+			ClockIdentity: peer.ClockIdentity,
+			PortNumber:    peer.PortNumber,
+			ActionField:   Response,
+			SmpteSync:     &SmpteSyncTlv{DefaultSystemFrameRate: FrameRate25},
+		}), seq: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := tt.m.Validate(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			bin, err := tt.m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			v, err := NewHeaderView(bin)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := uint8(24), v.DomainNumber(); want != got {
+				t.Fatalf("unexpected domainNumber: %v != %v", want, got)
+			}
+
+			if want, got := tt.seq, v.SequenceID(); want != got {
+				t.Fatalf("unexpected sequenceId: %v != %v", want, got)
+			}
+
+			if want, got := controlField(v.MessageType()), v.ControlField(); want != got {
+				t.Fatalf("unexpected controlField: %v != %v", want, got)
+			}
+
+			// Decoding honoring messageLength consumes the whole message
+			if err := StrictDecodeOptions.Unmarshal(bin, tt.m); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := tt.m.Validate(); err != nil {
+				t.Fatalf("unexpected error after decoding: %v", err)
+			}
+		})
+	}
+
+	if want, got := uint16(3), b.SequenceID(SyncMsgType); want != got {
+		t.Fatalf("unexpected next sequenceId: %v != %v", want, got)
+	}
+}
+
+func TestBuilderAnnounceFlags(t *testing.T) {
+	b := NewBuilder(PortIdentity{ClockIdentity: 0x000af7fffe42a753, PortNumber: 1}, 0)
+
+	flags := Flags{LI61: true, UtcReasonable: true, TimeScale: true, TimeTraceable: true, FrequencyTraceable: true}
+	a := b.Announce(AnnounceMsg{
+		Header:         Header{Flags: flags, MessageType: SyncMsgType, SequenceID: 9},
+		GMClockQuality: ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy25ns},
+		TimeSource:     TimeSourceGPS,
+	})
+
+	if want, got := flags, a.Flags; want != got {
+		t.Fatalf("unexpected flagField: %+v != %+v", want, got)
+	}
+
+	// The other header fields are those of the builder
+	if a.MessageType != AnnounceMsgType || a.SequenceID != 0 {
+		t.Fatalf("unexpected header: %+v", a.Header)
+	}
+}
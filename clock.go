@@ -0,0 +1,70 @@
+package ptp
+
+import "time"
+
+// Clock is a clock disciplined by a servo.
+type Clock interface {
+	// Now returns the current time of the clock.
+	Now() (time.Time, error)
+	// AdjustFrequency sets the frequency adjustment of the clock in ppb,
+	// positive to speed it up. It replaces the previous adjustment.
+	AdjustFrequency(ppb float64) error
+	// Step adds d to the time of the clock.
+	Step(d time.Duration) error
+	// MaxAdjustment returns the largest frequency adjustment of the clock
+	// in ppb.
+	MaxAdjustment() float64
+}
+
+// TimerClock is a Clock with a time for the timers of the protocol, such as
+// announceReceiptTimeout and the intervals of periodic messages. The time of
+// the clock itself is stepped and slewed by the servo, and a PTP hardware
+// clock counts TAI, while the timers need a steady time.
+type TimerClock interface {
+	Clock
+	// TimerNow returns the current time of the timers.
+	TimerNow() time.Time
+}
+
+// timerNow returns the time of the timers of c: TimerNow if c is a
+// TimerClock, the time of c otherwise, and time.Now if c is nil or fails.
+func timerNow(c Clock) time.Time {
+	switch c := c.(type) {
+	case nil:
+		return time.Now()
+	case TimerClock:
+		return c.TimerNow()
+	}
+
+	if now, err := c.Now(); err == nil {
+		return now
+	}
+	return time.Now()
+}
+
+// Discipline feeds offset, measured at local time ts, to s and applies its
+// adjustment to c: c is stepped by -offset in the ServoJump state, and its
+// frequency is adjusted in all states but ServoUnlocked. The adjustment is
+// bounded by the MaxAdjustment of c.
+func Discipline(c Clock, s Servo, offset time.Duration, ts time.Time) (ServoState, error) {
+	freq, state := s.Sample(offset, ts)
+
+	if state == ServoUnlocked {
+		return state, nil
+	}
+
+	if state == ServoJump {
+		if err := c.Step(-offset); err != nil {
+			return state, err
+		}
+	}
+
+	max := c.MaxAdjustment()
+	if freq > max {
+		freq = max
+	} else if freq < -max {
+		freq = -max
+	}
+
+	return state, c.AdjustFrequency(freq)
+}
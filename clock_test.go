@@ -0,0 +1,95 @@
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimClock(t *testing.T) {
+	ref := time.Unix(1000, 0)
+	c := NewSimClock(ref, time.Millisecond, 10000, 1)
+
+	now, err := c.Now()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, got := ref.Add(time.Millisecond), now; !want.Equal(got) {
+		t.Fatalf("unexpected time: %v != %v", want, got)
+	}
+
+	// The clock gains 10us per second, 4us with the adjustment
+	if err := c.AdjustFrequency(-6000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Advance(time.Second)
+	if want, got := time.Millisecond+4*time.Microsecond, c.Offset(); want != got {
+		t.Fatalf("unexpected offset: %v != %v", want, got)
+	}
+
+	if err := c.Step(-time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, got := 4*time.Microsecond, c.Offset(); want != got {
+		t.Fatalf("unexpected offset: %v != %v", want, got)
+	}
+
+	if err := c.AdjustFrequency(2 * DefaultSimMaxAdjustment); err != ErrOutOfRange {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Timestamps are noisy
+	c.Noise = time.Microsecond
+	now, _ = c.Now()
+	if d := now.Sub(c.Reference()) - c.Offset(); d == 0 || abs(d) > 10*time.Microsecond {
+		t.Fatalf("unexpected noise: %v", d)
+	}
+
+	// Frequency error wanders
+	c.Wander = 10
+	drift := c.Drift
+	c.Advance(time.Second)
+	if c.Drift == drift {
+		t.Fatal("expected wander")
+	}
+}
+
+func TestDiscipline(t *testing.T) {
+	c := NewSimClock(time.Unix(1000, 0), time.Millisecond, 300000, 1)
+	c.MaxAdj = 100000
+
+	s := NewPIServo(0)
+	states, _ := runServo(t, c, s, 2, time.Second)
+
+	if want, got := ServoJump, states[1]; want != got {
+		t.Fatalf("unexpected state: %v != %v", want, got)
+	}
+
+	// The adjustment is bounded by the clock
+	if want, got := -100000.0, c.Frequency(); want != got {
+		t.Fatalf("unexpected frequency: %v != %v", want, got)
+	}
+
+	// The clock gained 200us since the step
+	if want, got := 200*time.Microsecond, c.Offset(); abs(want-got) > time.Nanosecond {
+		t.Fatalf("unexpected offset: %v != %v", want, got)
+	}
+}
+
+func TestTimerNow(t *testing.T) {
+	ref := time.Unix(1000, 0)
+	c := NewSimClock(ref, 37*time.Second, 0, 1)
+
+	// The timers of a TimerClock do not follow its offset
+	if want, got := ref, timerNow(c); !want.Equal(got) {
+		t.Fatalf("unexpected timer time: %v != %v", want, got)
+	}
+
+	// Other clocks run the timers on their time
+	if want, got := ref.Add(37*time.Second), timerNow(struct{ Clock }{c}); !want.Equal(got) {
+		t.Fatalf("unexpected timer time: %v != %v", want, got)
+	}
+
+	if got := timerNow(nil); time.Since(got) > time.Second {
+		t.Fatalf("unexpected timer time: %v", got)
+	}
+}
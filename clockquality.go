@@ -3,6 +3,7 @@ package ptp
 import (
 	"encoding/binary"
 	"io"
+	"strconv"
 )
 
 // ClockClassType Type
@@ -10,12 +11,106 @@ type ClockClassType uint8
 
 // ClockClass types codes
 const (
-	PrimarySyncRefClass ClockClassType = 6
-	LostSyncClass       ClockClassType = 7
-	DefaultClass        ClockClassType = 248
-	SlaveOnlyClass      ClockClassType = 255
+	PrimarySyncRefClass              ClockClassType = 6
+	LostSyncClass                    ClockClassType = 7
+	ApplicationSpecificClass         ClockClassType = 13
+	ApplicationSpecificLostSyncClass ClockClassType = 14
+	DegradationAClass                ClockClassType = 52
+	DegradationAApplicationClass     ClockClassType = 58
+	DegradationBClass                ClockClassType = 187
+	DegradationBApplicationClass     ClockClassType = 193
+	DefaultClass                     ClockClassType = 248
+	SlaveOnlyClass                   ClockClassType = 255
 )
 
+// ClockClass codes defined by ITU-T G.8275.1/G.8275.2 telecom profiles
+const (
+	TelecomHoldoverInSpecClass      ClockClassType = 135
+	TelecomGMHoldoverCategory1Class ClockClassType = 140
+	TelecomGMHoldoverCategory2Class ClockClassType = 150
+	TelecomGMHoldoverCategory3Class ClockClassType = 160
+	TelecomHoldoverOutOfSpecClass   ClockClassType = 165
+)
+
+// ClockClassCategory groups clockClass values by their meaning
+// accordingly with IEEE 1588 table 5.
+type ClockClassCategory uint8
+
+// ClockClass categories
+const (
+	ClockClassReserved ClockClassCategory = iota
+	ClockClassPrimary
+	ClockClassHoldover
+	ClockClassDegraded
+	ClockClassAlternateProfile
+	ClockClassDefault
+	ClockClassSlaveOnly
+)
+
+// Category returns the IEEE 1588 category of the clockClass.
+func (c ClockClassType) Category() ClockClassCategory {
+	switch {
+	case c == PrimarySyncRefClass, c == ApplicationSpecificClass:
+		return ClockClassPrimary
+	case c == LostSyncClass, c == ApplicationSpecificLostSyncClass:
+		return ClockClassHoldover
+	case c == DegradationAClass, c == DegradationAApplicationClass,
+		c == DegradationBClass, c == DegradationBApplicationClass:
+		return ClockClassDegraded
+	case c >= 68 && c <= 122, c >= 133 && c <= 170, c >= 216 && c <= 232:
+		return ClockClassAlternateProfile
+	case c == DefaultClass:
+		return ClockClassDefault
+	case c == SlaveOnlyClass:
+		return ClockClassSlaveOnly
+	}
+	return ClockClassReserved
+}
+
+// IsApplicationSpecific reports whether the clockClass denotes a clock
+// synchronized to an application-specific (ARB) source of time.
+func (c ClockClassType) IsApplicationSpecific() bool {
+	switch c {
+	case
+		ApplicationSpecificClass,
+		ApplicationSpecificLostSyncClass,
+		DegradationAApplicationClass,
+		DegradationBApplicationClass:
+		return true
+	}
+	return false
+}
+
+// String returns the IEEE 1588 name of the clockClass.
+func (c ClockClassType) String() string {
+	switch c {
+	case PrimarySyncRefClass:
+		return "PrimaryReference"
+	case LostSyncClass:
+		return "PrimaryReferenceHoldover"
+	case ApplicationSpecificClass:
+		return "ApplicationSpecific"
+	case ApplicationSpecificLostSyncClass:
+		return "ApplicationSpecificHoldover"
+	case DegradationAClass:
+		return "DegradationA"
+	case DegradationAApplicationClass:
+		return "DegradationAApplicationSpecific"
+	case DegradationBClass:
+		return "DegradationB"
+	case DegradationBApplicationClass:
+		return "DegradationBApplicationSpecific"
+	case DefaultClass:
+		return "Default"
+	case SlaveOnlyClass:
+		return "SlaveOnly"
+	}
+	if c.Category() == ClockClassAlternateProfile {
+		return "AlternateProfile(" + strconv.Itoa(int(c)) + ")"
+	}
+	return "Reserved(" + strconv.Itoa(int(c)) + ")"
+}
+
 // ClockAccuracyType Type
 type ClockAccuracyType uint8
 
@@ -49,16 +144,11 @@ type ClockQuality struct {
 	ClockVariance uint16
 }
 
+// isValidClockClass reports whether the clockClass is not reserved by
+// IEEE 1588. Values assigned to alternate profiles are accepted, so that
+// messages of any profile can be decoded.
 func isValidClockClass(class ClockClassType) bool {
-	switch class {
-	case
-		PrimarySyncRefClass,
-		LostSyncClass,
-		DefaultClass,
-		SlaveOnlyClass:
-		return true
-	}
-	return false
+	return isValidClockClassForProfile(class, DefaultProfile)
 }
 
 func isValidClockAccuracy(c ClockAccuracyType) bool {
@@ -88,36 +178,81 @@ func isValidClockAccuracy(c ClockAccuracyType) bool {
 	return false
 }
 
-// MarshalBinary allocates a byte slice and marshals a Header into binary form.
+// MarshalBinary allocates a byte slice and marshals a ClockQuality into binary form.
 func (p ClockQuality) MarshalBinary() ([]byte, error) {
 
-	b := make([]byte, 4)
+	b := make([]byte, ClockQualityPayloadLen)
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// AppendBinary appends the binary form of a ClockQuality to b.
+func (p ClockQuality) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, ClockQualityPayloadLen, p.MarshalTo)
+}
+
+// MarshalTo marshals a ClockQuality into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p ClockQuality) MarshalTo(b []byte) (int, error) {
+	if len(b) < ClockQualityPayloadLen {
+		return 0, io.ErrShortBuffer
+	}
 
 	b[0] = uint8(p.ClockClass)
 	b[1] = uint8(p.ClockAccuracy)
 
 	binary.BigEndian.PutUint16(b[2:4], uint16(p.ClockVariance))
 
-	return b, nil
+	return ClockQualityPayloadLen, nil
 }
 
-// UnmarshalBinary unmarshals a byte slice into a Header.
+// UnmarshalBinary unmarshals a byte slice into a ClockQuality.
 func (p *ClockQuality) UnmarshalBinary(b []byte) error {
 	if len(b) != ClockQualityPayloadLen {
 		return io.ErrUnexpectedEOF
 	}
 
+	return p.unmarshal(b, &exactDecodeOptions)
+}
+
+// unmarshal decodes the ClockQuality at the start of b.
+func (p *ClockQuality) unmarshal(b []byte, o *DecodeOptions) error {
+	if len(b) < ClockQualityPayloadLen {
+		return io.ErrUnexpectedEOF
+	}
+
 	p.ClockClass = ClockClassType(b[0])
-	if !isValidClockClass(p.ClockClass) {
+	if !o.AllowUnknownValues && !isValidClockClass(p.ClockClass) {
 		return ErrInvalidClockClass
 	}
 
 	p.ClockAccuracy = ClockAccuracyType(b[1])
-	if !isValidClockAccuracy(p.ClockAccuracy) {
+	if !o.AllowUnknownValues && !isValidClockAccuracy(p.ClockAccuracy) {
 		return ErrInvalidClockAccuracy
 	}
 
-	p.ClockVariance = binary.BigEndian.Uint16(b[2:])
+	p.ClockVariance = binary.BigEndian.Uint16(b[2:4])
+
+	return nil
+}
+
+// Validate checks ClockQuality against the rules of the given profile.
+//
+// UnmarshalBinary only rejects clockClass values reserved by IEEE 1588.
+// Validate may be used afterwards to make sure the received clockClass is
+// one the profile allows.
+func (p ClockQuality) Validate(profile Profile) error {
+	if !isValidClockClassForProfile(p.ClockClass, profile) {
+		return ErrInvalidClockClass
+	}
+
+	if !isValidClockAccuracy(p.ClockAccuracy) {
+		return ErrInvalidClockAccuracy
+	}
 
 	return nil
 }
@@ -0,0 +1,147 @@
+package ptp
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestClockClassCategory(t *testing.T) {
+	var tests = []struct {
+		desc  string
+		class ClockClassType
+		cat   ClockClassCategory
+		name  string
+	}{
+		{desc: "Reserved zero", class: 0, cat: ClockClassReserved, name: "Reserved(0)"},
+		{desc: "Primary reference", class: 6, cat: ClockClassPrimary, name: "PrimaryReference"},
+		{desc: "Holdover", class: 7, cat: ClockClassHoldover, name: "PrimaryReferenceHoldover"},
+		{desc: "Reserved eight", class: 8, cat: ClockClassReserved, name: "Reserved(8)"},
+		{desc: "Application specific", class: 13, cat: ClockClassPrimary, name: "ApplicationSpecific"},
+		{desc: "Application specific holdover", class: 14, cat: ClockClassHoldover, name: "ApplicationSpecificHoldover"},
+		{desc: "Degradation A", class: 52, cat: ClockClassDegraded, name: "DegradationA"},
+		{desc: "Degradation A application specific", class: 58, cat: ClockClassDegraded, name: "DegradationAApplicationSpecific"},
+		{desc: "Alternate profile low bound", class: 68, cat: ClockClassAlternateProfile, name: "AlternateProfile(68)"},
+		{desc: "Reserved 123", class: 123, cat: ClockClassReserved, name: "Reserved(123)"},
+		{desc: "Telecom holdover", class: 135, cat: ClockClassAlternateProfile, name: "AlternateProfile(135)"},
+		{desc: "Telecom out of holdover", class: 165, cat: ClockClassAlternateProfile, name: "AlternateProfile(165)"},
+		{desc: "Degradation B", class: 187, cat: ClockClassDegraded, name: "DegradationB"},
+		{desc: "Degradation B application specific", class: 193, cat: ClockClassDegraded, name: "DegradationBApplicationSpecific"},
+		{desc: "Alternate profile high bound", class: 232, cat: ClockClassAlternateProfile, name: "AlternateProfile(232)"},
+		{desc: "Default", class: 248, cat: ClockClassDefault, name: "Default"},
+		{desc: "Reserved 251", class: 251, cat: ClockClassReserved, name: "Reserved(251)"},
+		{desc: "Slave only", class: 255, cat: ClockClassSlaveOnly, name: "SlaveOnly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.cat, tt.class.Category(); want != got {
+				t.Fatalf("unexpected category: %v != %v", want, got)
+			}
+
+			if want, got := tt.name, tt.class.String(); want != got {
+				t.Fatalf("unexpected name: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalClockQuality(t *testing.T) {
+	var tests = []struct {
+		desc string
+		q    *ClockQuality
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Primary reference",
+			q:    &ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 200},
+			b:    []byte{0x6, 0x21, 0x0, 0xc8},
+		},
+		{
+			desc: "Application specific",
+			q:    &ClockQuality{ClockClass: ApplicationSpecificClass, ClockAccuracy: ClockAccuracy1mics, ClockVariance: 0xffff},
+			b:    []byte{0xd, 0x23, 0xff, 0xff},
+		},
+		{
+			desc: "Degradation A",
+			q:    &ClockQuality{ClockClass: DegradationAClass, ClockAccuracy: ClockAccuracyNotSupported, ClockVariance: 0},
+			b:    []byte{0x34, 0xff, 0x0, 0x0},
+		},
+		{
+			desc: "Telecom holdover",
+			q:    &ClockQuality{ClockClass: TelecomHoldoverInSpecClass, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 0x4e5d},
+			b:    []byte{0x87, 0x21, 0x4e, 0x5d},
+		},
+		{
+			desc: "Reserved class",
+			b:    []byte{0x8, 0x21, 0x0, 0xc8},
+			err:  ErrInvalidClockClass,
+		},
+		{
+			desc: "Invalid accuracy",
+			b:    []byte{0x6, 0x1, 0x0, 0xc8},
+			err:  ErrInvalidClockAccuracy,
+		},
+		{
+			desc: "Invalid length",
+			b:    []byte{0x6, 0x21, 0x0},
+			err:  io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			q := new(ClockQuality)
+			err := q.UnmarshalBinary(tt.b)
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.q, q; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected ClockQuality:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestValidateClockQuality(t *testing.T) {
+	var tests = []struct {
+		desc    string
+		class   ClockClassType
+		profile Profile
+		err     error
+	}{
+		{desc: "Default profile, primary", class: 6, profile: DefaultProfile},
+		{desc: "Default profile, alternate profile class", class: 135, profile: DefaultProfile},
+		{desc: "Default profile, reserved", class: 9, profile: DefaultProfile, err: ErrInvalidClockClass},
+		{desc: "G.8275.1, locked", class: 6, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, holdover", class: 7, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, T-BC holdover", class: 135, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, GM holdover category 1", class: 140, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, GM holdover category 2", class: 150, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, GM holdover category 3", class: 160, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, T-BC out of holdover", class: 165, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, free run", class: 248, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, slave only", class: 255, profile: TelecomPhaseProfile},
+		{desc: "G.8275.1, degradation A", class: 52, profile: TelecomPhaseProfile, err: ErrInvalidClockClass},
+		{desc: "G.8275.2, degradation B", class: 187, profile: TelecomPartialTimingProfile, err: ErrInvalidClockClass},
+		{desc: "G.8265.1, QL-PRS", class: 80, profile: TelecomFrequencyProfile},
+		{desc: "G.8265.1, QL-PRC", class: 84, profile: TelecomFrequencyProfile},
+		{desc: "G.8265.1, QL-DNU", class: 110, profile: TelecomFrequencyProfile},
+		{desc: "G.8265.1, primary", class: 6, profile: TelecomFrequencyProfile, err: ErrInvalidClockClass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			q := ClockQuality{ClockClass: tt.class, ClockAccuracy: ClockAccuracy100ns}
+			if want, got := tt.err, q.Validate(tt.profile); want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package ptp
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// DecodeError describes why and where decoding of a message failed.
+//
+// Err holds one of the package errors, such as io.ErrUnexpectedEOF or
+// ErrInvalidClockClass, so DecodeError may be matched with errors.Is.
+type DecodeError struct {
+	// MsgType is the type of the message being decoded.
+	MsgType MsgType
+	// Field is the IEEE 1588 name of the field, empty if the error is not
+	// related to a particular field.
+	Field string
+	// Offset is the byte offset of the field from the start of the message.
+	Offset int
+	// Value is the offending value, nil if not applicable.
+	Value interface{}
+	Err   error
+}
+
+func (e *DecodeError) Error() string {
+	s := fmt.Sprintf("messageType %v", e.MsgType)
+	if e.Field != "" {
+		s += ", " + e.Field
+	}
+	s += fmt.Sprintf(" at offset %d", e.Offset)
+	if e.Value != nil {
+		s += fmt.Sprintf(" (%v)", e.Value)
+	}
+	return s + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError returns a DecodeError. If err already is a DecodeError, it is
+// returned unchanged, so the innermost location is reported.
+func decodeError(msgType MsgType, field string, offset int, value interface{}, err error) error {
+	if _, ok := err.(*DecodeError); ok {
+		return err
+	}
+
+	return &DecodeError{
+		MsgType: msgType,
+		Field:   field,
+		Offset:  offset,
+		Value:   value,
+		Err:     err,
+	}
+}
+
+// DecodeOptions controls how strictly messages are decoded. The zero value
+// requires the buffer to hold exactly one message with known field values.
+type DecodeOptions struct {
+	// HonorMessageLength makes messageLength of the header define the end
+	// of the message. Bytes between the fixed part of the message and
+	// messageLength are treated as TLVs. It is an error if messageLength
+	// is shorter than the fixed part or exceeds the buffer.
+	HonorMessageLength bool
+
+	// AllowPadding accepts bytes following the end of the message, such as
+	// padding of Ethernet frames to the 60 bytes minimum.
+	AllowPadding bool
+
+	// AllowUnknownValues accepts clockClass, clockAccuracy and timeSource
+	// values not defined by IEEE 1588.
+	AllowUnknownValues bool
+
+	// RejectReserved makes decoding fail with ErrReservedNotZero if a field
+	// or flag reserved by IEEE 1588-2008 is not zero. Otherwise the first
+	// such field is recorded in the Faults of the header.
+	RejectReserved bool
+
+	// RejectControlField makes decoding fail with ErrInvalidControlField if
+	// the deprecated controlField does not match messageType. Otherwise it
+	// is recorded in the Faults of the header.
+	RejectControlField bool
+}
+
+// DecodeFaults describes fields of a decoded message which MarshalBinary
+// derives from messageType or sends as zeros, and which the sender got
+// wrong. They are reported by Validate. The zero value describes a well
+// formed message.
+type DecodeFaults struct {
+	// ControlField is the controlField received, set along with
+	// WrongControlField if it does not match messageType.
+	ControlField      MsgCtrlType
+	WrongControlField bool
+
+	// ReservedOffset is the offset of the first reserved field or flag which
+	// is not zero, Reserved its value. ReservedOffset is 0 if all reserved
+	// fields are zero.
+	ReservedOffset int
+	Reserved       uint16
+}
+
+// Predefined decode options
+var (
+	// StrictDecodeOptions reject anything but a well formed message.
+	StrictDecodeOptions = DecodeOptions{
+		HonorMessageLength: true,
+		RejectReserved:     true,
+		RejectControlField: true,
+	}
+
+	// LenientDecodeOptions accept messages of third-party implementations
+	// as long as they can be decoded.
+	LenientDecodeOptions = DecodeOptions{
+		HonorMessageLength: true,
+		AllowPadding:       true,
+		AllowUnknownValues: true,
+	}
+)
+
+// Options of UnmarshalBinary methods. Messages are read from sockets along
+// with link layer padding, so bytes following a message of fixed length are
+// ignored. Bytes following the body of Announce, Signaling and Management
+// messages are decoded as TLVs, those too short for a TLV are ignored.
+// Headers and other fields are decoded from buffers of their exact length.
+//
+// Use StrictDecodeOptions or LenientDecodeOptions to honor messageLength.
+var (
+	// paddedDecodeOptions accept trailing bytes after the message
+	paddedDecodeOptions = DecodeOptions{AllowPadding: true}
+	// exactDecodeOptions require the buffer to hold exactly the field
+	exactDecodeOptions = DecodeOptions{}
+)
+
+// reservedFlagsMask covers flagField bits reserved by IEEE 1588-2008
+const reservedFlagsMask uint16 = 1<<6 | 1<<7 | 1<<11 | 1<<12
+
+// optionsUnmarshaler is implemented by types decodable with DecodeOptions.
+type optionsUnmarshaler interface {
+	unmarshal(b []byte, o *DecodeOptions) error
+}
+
+// Unmarshal decodes b into m accordingly with the options. m is expected to
+// be a pointer to Header, ClockQuality or one of the message types. Other
+// values are decoded with their UnmarshalBinary method.
+func (o DecodeOptions) Unmarshal(b []byte, m encoding.BinaryUnmarshaler) error {
+	if u, ok := m.(optionsUnmarshaler); ok {
+		return u.unmarshal(b, &o)
+	}
+
+	return m.UnmarshalBinary(b)
+}
+
+// message returns the part of b holding a message of type msgType. minLen is
+// the length of the header and the fixed part of the message body.
+func (o *DecodeOptions) message(b []byte, msgType MsgType, minLen int) ([]byte, error) {
+	if len(b) < minLen {
+		return nil, decodeError(msgType, "", len(b), nil, io.ErrUnexpectedEOF)
+	}
+
+	if !o.HonorMessageLength {
+		return b, nil
+	}
+
+	msgLen := int(binary.BigEndian.Uint16(b[2:4]))
+	if msgLen < minLen || msgLen > len(b) {
+		return nil, decodeError(msgType, "messageLength", 2, msgLen, io.ErrUnexpectedEOF)
+	}
+
+	if msgLen < len(b) && !o.AllowPadding {
+		return nil, decodeError(msgType, "padding", msgLen, len(b)-msgLen, io.ErrUnexpectedEOF)
+	}
+
+	return b[:msgLen], nil
+}
+
+// trailer checks bytes at offset of the message which are not decoded.
+// Within messageLength they are TLVs the package doesn't interpret, otherwise
+// they are padding.
+func (o *DecodeOptions) trailer(b []byte, msgType MsgType, offset int) error {
+	if len(b) > 0 && !o.HonorMessageLength && !o.AllowPadding {
+		return decodeError(msgType, "padding", offset, len(b), io.ErrUnexpectedEOF)
+	}
+
+	return nil
+}
+
+// walkTlvs calls fn for each TLV in b, which starts at offset of the message.
+// Trailing bytes too short to hold a TLV are checked with trailer.
+func (o *DecodeOptions) walkTlvs(b []byte, msgType MsgType, offset int, fn func(tlvType TlvType, tlv []byte) error) error {
+	for len(b) >= 4 {
+		tlvType := TlvType(binary.BigEndian.Uint16(b[0:2]))
+		tlvLen := 4 + int(binary.BigEndian.Uint16(b[2:4]))
+		if len(b) < tlvLen {
+			return decodeError(msgType, "lengthField", offset+2, tlvLen-4, io.ErrUnexpectedEOF)
+		}
+
+		if err := fn(tlvType, b[:tlvLen]); err != nil {
+			return decodeError(msgType, "tlv", offset, tlvType, err)
+		}
+		b = b[tlvLen:]
+		offset += tlvLen
+	}
+
+	return o.trailer(b, msgType, offset)
+}
+
+// reserved checks that b, located at offset of the message with header h, is
+// all zeros. It returns ErrReservedNotZero wrapped into DecodeError if the
+// options reject reserved fields, otherwise a violation is recorded in the
+// Faults of h.
+func (o *DecodeOptions) reserved(h *Header, b []byte, offset int) error {
+	for i, v := range b {
+		if v == 0 {
+			continue
+		}
+
+		if o.RejectReserved {
+			return decodeError(h.MessageType, "reserved", offset+i, v, ErrReservedNotZero)
+		}
+		h.Faults.reserved(offset+i, uint16(v))
+
+		return nil
+	}
+
+	return nil
+}
+
+// reserved records the reserved field at offset holding v, unless an earlier
+// one is recorded.
+func (f *DecodeFaults) reserved(offset int, v uint16) {
+	if f.ReservedOffset == 0 {
+		f.ReservedOffset, f.Reserved = offset, v
+	}
+}
@@ -0,0 +1,342 @@
+package ptp
+
+import (
+	"encoding"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// pdelReqBytes is a Pdelay_Req message followed by Ethernet padding
+var pdelReqBytes = []byte{0x2, 0x2, 0x0, 0x36, 0x0, 0x0, 0x0, 0x0,
+	0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+	0x0, 0x0, 0x0, 0x0,
+	0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x5, 0xfc,
+	// Reserved 20 bytes
+	0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+	0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+	// Padding
+	0x0, 0x0, 0x0, 0x0, 0x0, 0x0}
+
+func withByte(b []byte, i int, v byte) []byte {
+	c := append([]byte{}, b...)
+	c[i] = v
+	return c
+}
+
+func TestDecodeOptions(t *testing.T) {
+	announce := AnnounceMsg{
+		Header: Header{
+			MessageType:   AnnounceMsgType,
+			MessageLength: HeaderLen + AnnouncePayloadLen + 4,
+		},
+		GMClockQuality: ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracyNotSupported},
+		TimeSource:     TimeSourceInternalOsc,
+	}
+	announceBytes, _ := announce.MarshalBinary()
+
+	sync := &SyncMsg{Header: Header{MessageType: SyncMsgType}, OriginTimestamp: time.Unix(1, 2)}
+	syncBytes, _ := sync.MarshalBinary()
+
+	var tests = []struct {
+		desc string
+		o    DecodeOptions
+		m    encoding.BinaryUnmarshaler
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Padding rejected by default",
+			m:    new(PDelReqMsg),
+			b:    pdelReqBytes,
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "Padding allowed",
+			o:    DecodeOptions{AllowPadding: true},
+			m:    new(PDelReqMsg),
+			b:    pdelReqBytes,
+		},
+		{
+			desc: "Padding after messageLength allowed",
+			o:    DecodeOptions{HonorMessageLength: true, AllowPadding: true},
+			m:    new(PDelReqMsg),
+			b:    pdelReqBytes,
+		},
+		{
+			desc: "Padding after messageLength rejected",
+			o:    DecodeOptions{HonorMessageLength: true},
+			m:    new(PDelReqMsg),
+			b:    pdelReqBytes,
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "messageLength exact",
+			o:    DecodeOptions{HonorMessageLength: true},
+			m:    new(PDelReqMsg),
+			b:    pdelReqBytes[:HeaderLen+PDelayReqPayloadLen],
+		},
+		{
+			desc: "messageLength exceeds buffer",
+			o:    DecodeOptions{HonorMessageLength: true},
+			m:    new(PDelReqMsg),
+			b:    withByte(pdelReqBytes, 3, 0x40),
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "messageLength shorter than message",
+			o:    DecodeOptions{HonorMessageLength: true, AllowPadding: true},
+			m:    new(SyncMsg),
+			b:    withByte(syncBytes, 3, 0x2b),
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "Trailing bytes of Sync rejected",
+			m:    new(SyncMsg),
+			b:    append(append([]byte{}, syncBytes...), 0x0, 0x0),
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "Unknown clockClass rejected",
+			m:    new(AnnounceMsg),
+			b:    withByte(announceBytes, 48, 0x8),
+			err:  ErrInvalidClockClass,
+		},
+		{
+			desc: "Unknown clockClass allowed",
+			o:    DecodeOptions{AllowUnknownValues: true},
+			m:    new(AnnounceMsg),
+			b:    withByte(announceBytes, 48, 0x8),
+		},
+		{
+			desc: "Unknown clockAccuracy allowed",
+			o:    DecodeOptions{AllowUnknownValues: true},
+			m:    new(AnnounceMsg),
+			b:    withByte(announceBytes, 49, 0x1),
+		},
+		{
+			desc: "Unknown timeSource allowed",
+			o:    DecodeOptions{AllowUnknownValues: true},
+			m:    new(AnnounceMsg),
+			b:    withByte(announceBytes, 63, 0x21),
+		},
+		{
+			desc: "Reserved flag ignored",
+			m:    new(SyncMsg),
+			b:    withByte(syncBytes, 6, 0x08),
+		},
+		{
+			desc: "Reserved flag rejected",
+			o:    DecodeOptions{RejectReserved: true},
+			m:    new(SyncMsg),
+			b:    withByte(syncBytes, 6, 0x08),
+			err:  ErrReservedNotZero,
+		},
+		{
+			desc: "Reserved header byte rejected",
+			o:    DecodeOptions{RejectReserved: true},
+			m:    new(SyncMsg),
+			b:    withByte(syncBytes, 17, 0x1),
+			err:  ErrReservedNotZero,
+		},
+		{
+			desc: "Reserved Announce byte rejected",
+			o:    StrictDecodeOptions,
+			m:    new(AnnounceMsg),
+			b:    withByte(announceBytes, 46, 0x1),
+			err:  ErrReservedNotZero,
+		},
+		{
+			desc: "controlField ignored",
+			m:    new(SyncMsg),
+			b:    withByte(syncBytes, 32, 0x5),
+		},
+		{
+			desc: "controlField rejected",
+			o:    DecodeOptions{RejectControlField: true},
+			m:    new(SyncMsg),
+			b:    withByte(syncBytes, 32, 0x5),
+			err:  ErrInvalidControlField,
+		},
+		{
+			desc: "Reserved Pdelay_Req bytes rejected",
+			o:    DecodeOptions{RejectReserved: true, AllowPadding: true},
+			m:    new(PDelReqMsg),
+			b:    withByte(pdelReqBytes, 50, 0x1),
+			err:  ErrReservedNotZero,
+		},
+		{
+			desc: "Strict Announce",
+			o:    StrictDecodeOptions,
+			m:    new(AnnounceMsg),
+			b:    announceBytes,
+		},
+		{
+			desc: "Lenient Announce",
+			o:    LenientDecodeOptions,
+			m:    new(AnnounceMsg),
+			b:    append(withByte(announceBytes, 48, 0x8), 0x0, 0x0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.err, tt.o.Unmarshal(tt.b, tt.m); !errors.Is(got, want) {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeOptionsTlvs(t *testing.T) {
+	m := AnnounceMsg{
+		Header: Header{
+			MessageType:   AnnounceMsgType,
+			MessageLength: HeaderLen + AnnouncePayloadLen + 4 + PowerProfileTlvLen + 4,
+		},
+		GMClockQuality: ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracyNotSupported},
+		TimeSource:     TimeSourceInternalOsc,
+		PowerProfile:   &PowerProfileTlv{GrandmasterID: 1},
+	}
+	b, _ := m.MarshalBinary()
+	// Padding that could be mistaken for a TLV
+	b = append(b, 0x0, 0x3, 0x0, 0x10)
+
+	got := new(AnnounceMsg)
+	if err := LenientDecodeOptions.Unmarshal(b, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.PowerProfile == nil || got.PowerProfile.GrandmasterID != 1 {
+		t.Fatalf("unexpected power profile TLV: %#v", got.PowerProfile)
+	}
+
+	if err := got.UnmarshalBinary(b); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestUnmarshalBinaryPadding(t *testing.T) {
+	ts := time.Unix(500, 200)
+	announce := &AnnounceMsg{
+		Header:         Header{MessageType: AnnounceMsgType},
+		GMClockQuality: ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracyNotSupported},
+		TimeSource:     TimeSourceInternalOsc,
+	}
+
+	// Messages are padded to the 60 bytes of the shortest Ethernet frame
+	var tests = []struct {
+		desc string
+		m    interface {
+			encoding.BinaryMarshaler
+			encoding.BinaryUnmarshaler
+		}
+	}{
+		{desc: "Sync", m: &SyncMsg{Header: Header{MessageType: SyncMsgType}, OriginTimestamp: ts}},
+		{desc: "Delay_Req", m: &DelReqMsg{Header: Header{MessageType: DelayReqMsgType}, OriginTimestamp: ts}},
+		{desc: "Follow_Up", m: &FollowUpMsg{Header: Header{MessageType: FollowUpMsgType}, PreciseOriginTimestamp: ts}},
+		{desc: "Delay_Resp", m: &DelRespMsg{Header: Header{MessageType: DelayRespMsgType}, ReceiveTimestamp: ts}},
+		{desc: "Announce", m: announce},
+		{desc: "Management", m: &MgmtMsg{Header: Header{MessageType: MgmtMsgType}, ActionField: Get}},
+		{desc: "Pdelay_Req", m: &PDelReqMsg{Header: Header{MessageType: PDelayReqMsgType}}},
+		{desc: "Pdelay_Resp", m: &PDelRespMsg{Header: Header{MessageType: PDelayRespMsgType}, ReceiveTimestamp: ts}},
+		{desc: "Pdelay_Resp_Follow_Up", m: &PDelRespFollowUpMsg{Header: Header{MessageType: PDelayRespFollowUpMsgType}, OriginTimestamp: ts}},
+		{desc: "Signaling", m: &SignalingMsg{Header: Header{MessageType: SignalingMsgType}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			padding := 60 - len(b)
+			if padding < 2 {
+				padding = 2
+			}
+
+			if err := tt.m.UnmarshalBinary(append(b, make([]byte, padding)...)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	announce := AnnounceMsg{
+		Header:         Header{MessageType: AnnounceMsgType},
+		GMClockQuality: ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracyNotSupported},
+		TimeSource:     TimeSourceInternalOsc,
+	}
+	announceBytes, _ := announce.MarshalBinary()
+
+	var tests = []struct {
+		desc string
+		o    DecodeOptions
+		m    encoding.BinaryUnmarshaler
+		b    []byte
+		want DecodeError
+	}{
+		{
+			desc: "Invalid clockClass",
+			o:    DecodeOptions{AllowPadding: true},
+			m:    new(AnnounceMsg),
+			b:    withByte(announceBytes, 48, 0x8),
+			want: DecodeError{AnnounceMsgType, "grandmasterClockQuality.clockClass", 48, uint8(0x8), ErrInvalidClockClass},
+		},
+		{
+			desc: "Invalid timeSource",
+			o:    DecodeOptions{AllowPadding: true},
+			m:    new(AnnounceMsg),
+			b:    withByte(announceBytes, 63, 0x21),
+			want: DecodeError{AnnounceMsgType, "timeSource", 63, TimeSourceType(0x21), ErrInvalidTimeSource},
+		},
+		{
+			desc: "Unexpected messageType",
+			m:    new(SyncMsg),
+			b:    announceBytes,
+			want: DecodeError{SyncMsgType, "messageType", 0, AnnounceMsgType, ErrInvalidMsgType},
+		},
+		{
+			desc: "Truncated message",
+			m:    new(PDelReqMsg),
+			b:    pdelReqBytes[:40],
+			want: DecodeError{PDelayReqMsgType, "", 40, nil, io.ErrUnexpectedEOF},
+		},
+		{
+			desc: "messageLength exceeds buffer",
+			o:    DecodeOptions{HonorMessageLength: true},
+			m:    new(PDelReqMsg),
+			b:    withByte(pdelReqBytes, 3, 0x40),
+			want: DecodeError{PDelayReqMsgType, "messageLength", 2, 0x40, io.ErrUnexpectedEOF},
+		},
+		{
+			desc: "Reserved byte",
+			o:    StrictDecodeOptions,
+			m:    new(PDelReqMsg),
+			b:    withByte(pdelReqBytes[:HeaderLen+PDelayReqPayloadLen], 50, 0x1),
+			want: DecodeError{PDelayReqMsgType, "reserved", 50, uint8(0x1), ErrReservedNotZero},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.o.Unmarshal(tt.b, tt.m)
+
+			var got *DecodeError
+			if !errors.As(err, &got) {
+				t.Fatalf("unexpected error type: %T", err)
+			}
+
+			if tt.want != *got {
+				t.Fatalf("unexpected error: %#v != %#v", tt.want, *got)
+			}
+
+			if !errors.Is(err, tt.want.Err) {
+				t.Fatalf("error does not match %v", tt.want.Err)
+			}
+		})
+	}
+}
@@ -14,41 +14,78 @@ type DelReqMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *DelReqMsg) MarshalBinary() ([]byte, error) {
 
-	if t.Header.MessageType != DelayReqMsgType {
-		return nil, ErrInvalidMsgType
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
 	}
 
-	b := make([]byte, HeaderLen+DelayReqPayloadLen)
+	return b, nil
+}
 
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+// MarshalLen returns the length of a DelReqMsg in binary form.
+func (t *DelReqMsg) MarshalLen() int {
+	return HeaderLen + DelayReqPayloadLen
+}
+
+// AppendBinary appends the binary form of a DelReqMsg to b.
+func (t *DelReqMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a DelReqMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *DelReqMsg) MarshalTo(b []byte) (int, error) {
+	if t.Header.MessageType != DelayReqMsgType {
+		return 0, ErrInvalidMsgType
 	}
 
-	copy(b[:HeaderLen], headerSlice)
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
 
-	time2OriginTimestamp(t.OriginTimestamp, b[HeaderLen:])
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 
-	return b, nil
+	time2OriginTimestamp(t.OriginTimestamp, b[HeaderLen:n])
+
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a DelReqMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid DelReqMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are ignored.
 func (t *DelReqMsg) UnmarshalBinary(b []byte) error {
-	if len(b) < HeaderLen+DelayReqPayloadLen {
-		return io.ErrUnexpectedEOF
-	}
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
 
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
+// unmarshal decodes a DelReqMsg accordingly with the options.
+func (t *DelReqMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, DelayReqMsgType, HeaderLen+DelayReqPayloadLen)
 	if err != nil {
 		return err
 	}
 
-	if t.OriginTimestamp, err = originTimestamp2Time(b[HeaderLen:]); err != nil {
+	if err = t.Header.unmarshal(b, o); err != nil {
 		return err
 	}
 
-	return nil
+	if t.Header.MessageType != DelayReqMsgType {
+		return decodeError(DelayReqMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
+	}
+
+	if t.OriginTimestamp, err = originTimestamp2Time(b[HeaderLen : HeaderLen+OriginTimestampFullLen]); err != nil {
+		return decodeError(DelayReqMsgType, "originTimestamp", HeaderLen, nil, err)
+	}
+
+	return o.trailer(b[HeaderLen+DelayReqPayloadLen:], DelayReqMsgType, HeaderLen+DelayReqPayloadLen)
+}
+
+// Validate checks that a DelReqMsg conforms to IEEE 1588.
+func (t *DelReqMsg) Validate() error {
+	return t.Header.validate(DelayReqMsgType, t.MarshalLen(), false)
 }
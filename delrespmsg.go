@@ -17,44 +17,92 @@ type DelRespMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *DelRespMsg) MarshalBinary() ([]byte, error) {
 
-	if t.Header.MessageType != DelayRespMsgType {
-		return nil, ErrInvalidMsgType
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
 	}
 
-	b := make([]byte, HeaderLen+DelayRespPayloadLen)
+	return b, nil
+}
 
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+// MarshalLen returns the length of a DelRespMsg in binary form.
+func (t *DelRespMsg) MarshalLen() int {
+	return HeaderLen + DelayRespPayloadLen
+}
+
+// AppendBinary appends the binary form of a DelRespMsg to b.
+func (t *DelRespMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a DelRespMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *DelRespMsg) MarshalTo(b []byte) (int, error) {
+	if t.Header.MessageType != DelayRespMsgType {
+		return 0, ErrInvalidMsgType
 	}
 
-	copy(b[:HeaderLen], headerSlice)
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
+
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 	offset := HeaderLen
 
-	//TODO: add receiveTimestamp
-	offset += 10
+	time2OriginTimestamp(t.ReceiveTimestamp, b[offset:offset+OriginTimestampFullLen])
+	offset += OriginTimestampFullLen
 
-	portIdentitySlice := make([]byte, 8)
-	binary.BigEndian.PutUint64(portIdentitySlice, t.RequestingPortIdentity)
-	copy(b[offset:offset+8], portIdentitySlice)
-	offset += 8
+	binary.BigEndian.PutUint64(b[offset:offset+ClockIdentityLen], t.RequestingPortIdentity)
+	offset += ClockIdentityLen
 
-	portIDSlice := make([]byte, 2)
-	binary.BigEndian.PutUint16(portIDSlice, t.RequestingPortID)
-	copy(b[offset:offset+2], portIDSlice)
+	binary.BigEndian.PutUint16(b[offset:offset+SourcePortNumberLen], t.RequestingPortID)
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a DelRespMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid DelRespMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are ignored.
 func (t *DelRespMsg) UnmarshalBinary(b []byte) error {
-	// Must contain type and length values
-	if len(b) < HeaderLen+DelayRespPayloadLen {
-		return io.ErrUnexpectedEOF
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
+
+// unmarshal decodes a DelRespMsg accordingly with the options.
+func (t *DelRespMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, DelayRespMsgType, HeaderLen+DelayRespPayloadLen)
+	if err != nil {
+		return err
 	}
 
-	return ErrInvalidFrame
+	if err = t.Header.unmarshal(b, o); err != nil {
+		return err
+	}
+
+	if t.Header.MessageType != DelayRespMsgType {
+		return decodeError(DelayRespMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
+	}
+
+	if t.ReceiveTimestamp, err = originTimestamp2Time(b[HeaderLen : HeaderLen+OriginTimestampFullLen]); err != nil {
+		return decodeError(DelayRespMsgType, "receiveTimestamp", HeaderLen, nil, err)
+	}
+	offset := HeaderLen + OriginTimestampFullLen
+
+	t.RequestingPortIdentity = binary.BigEndian.Uint64(b[offset : offset+ClockIdentityLen])
+	offset += ClockIdentityLen
+
+	t.RequestingPortID = binary.BigEndian.Uint16(b[offset : offset+SourcePortNumberLen])
+	offset += SourcePortNumberLen
+
+	return o.trailer(b[offset:], DelayRespMsgType, offset)
+}
+
+// Validate checks that a DelRespMsg conforms to IEEE 1588.
+func (t *DelRespMsg) Validate() error {
+	return t.Header.validate(DelayRespMsgType, t.MarshalLen(), false)
 }
@@ -0,0 +1,175 @@
+package ptp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var delRespBytes = []byte{0x9, 0x2, 0x0, 0x36, 0x0, 0x0, 0x0, 0x0,
+	0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+	0x0, 0x0, 0x0, 0x0,
+	0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x3, 0x0,
+	// Receive timestamp
+	0x0, 0x0, 0x45, 0xb1, 0x11, 0x49, 0x2e, 0x32, 0x42, 0x63,
+	// Requesting port identity
+	0x0, 0x1d, 0x7f, 0xff, 0xfe, 0x80, 0x2, 0x4a, 0x0, 0x1}
+
+func TestMarshalDelResp(t *testing.T) {
+	var tests = []struct {
+		desc string
+		m    *DelRespMsg
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Correct structure",
+			m: &DelRespMsg{
+				Header: Header{
+					MessageType:   DelayRespMsgType,
+					MessageLength: HeaderLen + DelayRespPayloadLen,
+					ClockIdentity: 0x000af7fffe42a753,
+					PortNumber:    2,
+					SequenceID:    55330,
+				},
+				ReceiveTimestamp:       time.Unix(1169232201, 775045731),
+				RequestingPortIdentity: 0x001d7ffffe80024a,
+				RequestingPortID:       1,
+			},
+			b: delRespBytes,
+		},
+		{
+			desc: "Receive timestamp",
+			m: &DelRespMsg{
+				Header: Header{
+					MessageType:   DelayRespMsgType,
+					MessageLength: HeaderLen + DelayRespPayloadLen,
+				},
+				ReceiveTimestamp: time.Unix(0x123456789a, 999999999),
+			},
+			b: []byte{0x9, 0x2, 0x0, 0x36, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x3, 0x0,
+				// Receive timestamp
+				0x0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0x3b, 0x9a, 0xc9, 0xff,
+				// Requesting port identity
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0},
+		},
+		{
+			desc: "Invalid message type",
+			m: &DelRespMsg{
+				Header: Header{
+					MessageType: DelayReqMsgType,
+				},
+			},
+			err: ErrInvalidMsgType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.m.MarshalBinary()
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalDelResp(t *testing.T) {
+	var tests = []struct {
+		desc string
+		m    *DelRespMsg
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Correct structure",
+			m: &DelRespMsg{
+				Header: Header{
+					MessageType:   DelayRespMsgType,
+					MessageLength: HeaderLen + DelayRespPayloadLen,
+					VersionPTP:    Version2,
+					ClockIdentity: 0x000af7fffe42a753,
+					PortNumber:    2,
+					SequenceID:    55330,
+				},
+				ReceiveTimestamp:       time.Unix(1169232201, 775045731),
+				RequestingPortIdentity: 0x001d7ffffe80024a,
+				RequestingPortID:       1,
+			},
+			b: delRespBytes,
+		},
+		{
+			desc: "Invalid message type",
+			b:    withByte(delRespBytes, 0, 0x1),
+			err:  ErrInvalidMsgType,
+		},
+		{
+			desc: "Invalid length",
+			b:    delRespBytes[:len(delRespBytes)-1],
+			err:  io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := new(DelRespMsg)
+			err := m.UnmarshalBinary(tt.b)
+			if err != nil {
+				if want, got := tt.err, err; !errors.Is(got, want) {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.m, m; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestDelRespRoundTrip(t *testing.T) {
+	want := DelRespMsg{
+		Header: Header{
+			MessageType:   DelayRespMsgType,
+			MessageLength: HeaderLen + DelayRespPayloadLen,
+			VersionPTP:    Version2,
+			CorrectionNs:  250,
+			ClockIdentity: 0x000af7fffe42a753,
+			PortNumber:    2,
+			SequenceID:    7,
+		},
+		ReceiveTimestamp:       time.Unix(1700000000, 123456789),
+		RequestingPortIdentity: 0x001d7ffffe80024a,
+		RequestingPortID:       1,
+	}
+
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got DelRespMsg
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Delay_Resp:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
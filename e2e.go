@@ -0,0 +1,160 @@
+package ptp
+
+import "time"
+
+// E2EMeasurement is the result of a Sync or delay request-response exchange
+// of an E2ESlave, IEEE 1588-2008 11.3.
+type E2EMeasurement struct {
+	OffsetFromMaster time.Duration
+	MeanPathDelay    time.Duration
+	// DelayMeasured tells whether MeanPathDelay was measured, it is 0
+	// until the first Delay_Resp is received
+	DelayMeasured bool
+	// SyncReceived is the receipt time t2 of the Sync the offset is
+	// computed from
+	SyncReceived time.Time
+}
+
+// e2eSync holds the timestamps of a Sync and its Follow_Up.
+type e2eSync struct {
+	seq uint16
+	// t1 is the originTimestamp, t2 the receipt time
+	t1, t2 time.Time
+	// correction of the Sync and Follow_Up
+	correction time.Duration
+}
+
+// E2ESlave computes offsetFromMaster and meanPathDelay of a slave port with
+// the delay request-response mechanism. It is fed the messages of the master
+// and the local timestamps, so it does not depend on a transport.
+//
+// Two-step Sync messages are matched with their Follow_Up and Delay_Req with
+// Delay_Resp by sequenceId. Messages from ports other than the master are
+// ignored.
+//
+// An E2ESlave is not safe for concurrent use.
+type E2ESlave struct {
+	builder *Builder
+	master  PortIdentity
+
+	// pending is the two-step Sync waiting for its Follow_Up
+	pending    e2eSync
+	hasPending bool
+	// last is the last complete Sync
+	last    e2eSync
+	hasLast bool
+
+	// req is the last Delay_Req, t3 its transmission time
+	req     uint16
+	t3      time.Time
+	hasReq  bool
+	reqSent bool
+
+	meanPathDelay time.Duration
+	delayMeasured bool
+}
+
+// NewE2ESlave returns an E2ESlave synchronizing to master, which sends
+// Delay_Req messages built by b.
+func NewE2ESlave(b *Builder, master PortIdentity) *E2ESlave {
+	return &E2ESlave{builder: b, master: master}
+}
+
+// Master returns the identity of the master port.
+func (s *E2ESlave) Master() PortIdentity {
+	return s.master
+}
+
+// SetMaster changes the master port and discards the measurements of the
+// previous one.
+func (s *E2ESlave) SetMaster(master PortIdentity) {
+	*s = E2ESlave{builder: s.builder, master: master}
+}
+
+// HandleSync records m received at t2. A one-step Sync completes an exchange
+// and its measurement is returned, a two-step Sync waits for its Follow_Up.
+func (s *E2ESlave) HandleSync(m *SyncMsg, t2 time.Time) (E2EMeasurement, bool) {
+	if m.SourcePortIdentity() != s.master {
+		return E2EMeasurement{}, false
+	}
+
+	sync := e2eSync{seq: m.SequenceID, t1: m.OriginTimestamp, t2: t2, correction: m.Correction()}
+	if m.TwoSteps {
+		s.pending, s.hasPending = sync, true
+		return E2EMeasurement{}, false
+	}
+
+	s.hasPending = false
+
+	return s.complete(sync), true
+}
+
+// HandleFollowUp completes the two-step Sync of m and returns its
+// measurement. Follow_Up messages not matching the last Sync are stale and
+// ignored.
+func (s *E2ESlave) HandleFollowUp(m *FollowUpMsg) (E2EMeasurement, bool) {
+	if m.SourcePortIdentity() != s.master || !s.hasPending || m.SequenceID != s.pending.seq {
+		return E2EMeasurement{}, false
+	}
+
+	sync := s.pending
+	sync.t1 = m.PreciseOriginTimestamp
+	sync.correction += m.Correction()
+	s.hasPending = false
+
+	return s.complete(sync), true
+}
+
+func (s *E2ESlave) complete(sync e2eSync) E2EMeasurement {
+	s.last, s.hasLast = sync, true
+	return s.measurement()
+}
+
+// measurement returns the measurement of the last Sync, offsetFromMaster =
+// t2 - t1 - meanPathDelay - correction.
+func (s *E2ESlave) measurement() E2EMeasurement {
+	return E2EMeasurement{
+		OffsetFromMaster: s.last.t2.Sub(s.last.t1) - s.meanPathDelay - s.last.correction,
+		MeanPathDelay:    s.meanPathDelay,
+		DelayMeasured:    s.delayMeasured,
+		SyncReceived:     s.last.t2,
+	}
+}
+
+// DelayReq returns the next Delay_Req message. Its transmission time is
+// recorded with DelayReqSent.
+func (s *E2ESlave) DelayReq() *DelReqMsg {
+	m := s.builder.DelayReq(time.Unix(0, 0))
+	s.req, s.hasReq, s.reqSent = m.SequenceID, true, false
+
+	return m
+}
+
+// DelayReqSent records the transmission time t3 of m.
+func (s *E2ESlave) DelayReqSent(m *DelReqMsg, t3 time.Time) {
+	if s.hasReq && m.SequenceID == s.req {
+		s.t3, s.reqSent = t3, true
+	}
+}
+
+// HandleDelayResp completes the delay request-response exchange of m and
+// returns the measurement of the last Sync with the new meanPathDelay =
+// ((t2 - t3) + (t4 - t1) - corrections) / 2. Delay_Resp messages not
+// answering the last Delay_Req of the port are ignored.
+func (s *E2ESlave) HandleDelayResp(m *DelRespMsg) (E2EMeasurement, bool) {
+	requester := PortIdentity{ClockIdentity: m.RequestingPortIdentity, PortNumber: m.RequestingPortID}
+	if m.SourcePortIdentity() != s.master || requester != s.builder.PortIdentity {
+		return E2EMeasurement{}, false
+	}
+
+	if !s.hasReq || !s.reqSent || m.SequenceID != s.req || !s.hasLast {
+		return E2EMeasurement{}, false
+	}
+	s.hasReq = false
+
+	d := s.last.t2.Sub(s.t3) + m.ReceiveTimestamp.Sub(s.last.t1) - s.last.correction - m.Correction()
+	s.meanPathDelay = d / 2
+	s.delayMeasured = true
+
+	return s.measurement(), true
+}
@@ -0,0 +1,153 @@
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestE2ESlave(t *testing.T) {
+	const (
+		// The slave clock is ahead of the master by offset
+		offset = 100 * time.Microsecond
+		delay  = 5 * time.Microsecond
+		// Residence time in transparent clocks added to correctionField
+		syncResidence = 2 * time.Microsecond
+		respResidence = time.Microsecond
+	)
+
+	masterID := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+	slaveID := PortIdentity{ClockIdentity: 0x000af7fffe000002, PortNumber: 1}
+	t1 := time.Unix(1000, 0)
+
+	var tests = []struct {
+		desc    string
+		twoStep bool
+	}{
+		{desc: "One-step"},
+		{desc: "Two-step", twoStep: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			master := NewBuilder(masterID, 0)
+			master.TwoStep = tt.twoStep
+			s := NewE2ESlave(NewBuilder(slaveID, 0), masterID)
+
+			// Sync received before any delay was measured
+			sync := master.Sync(t1)
+			sync.SetCorrection(syncResidence)
+			t2 := t1.Add(delay + syncResidence + offset)
+
+			got, ok := s.HandleSync(sync, t2)
+			if tt.twoStep {
+				if ok {
+					t.Fatal("unexpected measurement of two-step Sync")
+				}
+
+				followUp := master.FollowUp(sync, t1)
+				got, ok = s.HandleFollowUp(followUp)
+			}
+
+			want := E2EMeasurement{OffsetFromMaster: offset + delay, SyncReceived: t2}
+			if !ok || want != got {
+				t.Fatalf("unexpected measurement of Sync: %+v != %+v", want, got)
+			}
+
+			req := s.DelayReq()
+			if want, got := slaveID, req.SourcePortIdentity(); want != got {
+				t.Fatalf("unexpected Delay_Req source: %v != %v", want, got)
+			}
+
+			t3 := t2.Add(time.Millisecond)
+			s.DelayReqSent(req, t3)
+
+			resp := master.DelayResp(req, t3.Add(-offset+delay+respResidence))
+			resp.SetCorrection(respResidence)
+
+			got, ok = s.HandleDelayResp(resp)
+			want = E2EMeasurement{OffsetFromMaster: offset, MeanPathDelay: delay, DelayMeasured: true, SyncReceived: t2}
+			if !ok || want != got {
+				t.Fatalf("unexpected measurement of Delay_Resp: %+v != %+v", want, got)
+			}
+
+			// The same Delay_Resp is not used twice
+			if _, ok := s.HandleDelayResp(resp); ok {
+				t.Fatal("unexpected measurement of duplicate Delay_Resp")
+			}
+
+			// Following Sync messages use the measured delay
+			sync = master.Sync(t1.Add(time.Second))
+			t2 = t1.Add(time.Second + delay + offset)
+			got, ok = s.HandleSync(sync, t2)
+			if tt.twoStep {
+				got, ok = s.HandleFollowUp(master.FollowUp(sync, t1.Add(time.Second)))
+			}
+
+			want = E2EMeasurement{OffsetFromMaster: offset, MeanPathDelay: delay, DelayMeasured: true, SyncReceived: t2}
+			if !ok || want != got {
+				t.Fatalf("unexpected measurement of next Sync: %+v != %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestE2ESlaveIgnored(t *testing.T) {
+	masterID := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+	otherID := PortIdentity{ClockIdentity: 0x000af7fffe000003, PortNumber: 1}
+	slaveID := PortIdentity{ClockIdentity: 0x000af7fffe000002, PortNumber: 1}
+	now := time.Unix(1000, 0)
+
+	master := NewBuilder(masterID, 0)
+	master.TwoStep = true
+	other := NewBuilder(otherID, 0)
+	other.TwoStep = true
+	s := NewE2ESlave(NewBuilder(slaveID, 0), masterID)
+
+	if _, ok := s.HandleSync(other.Sync(now), now); ok {
+		t.Fatal("unexpected measurement of Sync from another port")
+	}
+
+	if _, ok := s.HandleFollowUp(master.FollowUp(master.Sync(now), now)); ok {
+		t.Fatal("unexpected measurement of Follow_Up without Sync")
+	}
+
+	stale := master.Sync(now)
+	s.HandleSync(master.Sync(now), now)
+	if _, ok := s.HandleFollowUp(master.FollowUp(stale, now)); ok {
+		t.Fatal("unexpected measurement of stale Follow_Up")
+	}
+
+	// Delay_Resp before any Sync completed
+	req := s.DelayReq()
+	s.DelayReqSent(req, now)
+	if _, ok := s.HandleDelayResp(master.DelayResp(req, now)); ok {
+		t.Fatal("unexpected measurement of Delay_Resp without Sync")
+	}
+
+	s.HandleSync(other.Sync(now), now)
+	sync := master.Sync(now)
+	s.HandleSync(sync, now)
+	if _, ok := s.HandleFollowUp(master.FollowUp(sync, now)); !ok {
+		t.Fatal("expected measurement of Follow_Up")
+	}
+
+	// Delay_Resp to another port
+	req = s.DelayReq()
+	s.DelayReqSent(req, now)
+	other.PortIdentity = slaveID
+	resp := master.DelayResp(other.DelayReq(now), now)
+	resp.RequestingPortID = 2
+	if _, ok := s.HandleDelayResp(resp); ok {
+		t.Fatal("unexpected measurement of Delay_Resp to another port")
+	}
+
+	// Delay_Resp from another master
+	if _, ok := s.HandleDelayResp(NewBuilder(otherID, 0).DelayResp(req, now)); ok {
+		t.Fatal("unexpected measurement of Delay_Resp from another port")
+	}
+
+	s.SetMaster(otherID)
+	if _, ok := s.HandleDelayResp(master.DelayResp(req, now)); ok {
+		t.Fatal("unexpected measurement of Delay_Resp after master change")
+	}
+}
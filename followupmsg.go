@@ -14,8 +14,30 @@ type FollowUpMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *FollowUpMsg) MarshalBinary() ([]byte, error) {
 
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a FollowUpMsg in binary form.
+func (t *FollowUpMsg) MarshalLen() int {
+	return HeaderLen + FollowUpPayloadLen
+}
+
+// AppendBinary appends the binary form of a FollowUpMsg to b.
+func (t *FollowUpMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a FollowUpMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *FollowUpMsg) MarshalTo(b []byte) (int, error) {
 	if t.Header.MessageType != FollowUpMsgType {
-		return nil, ErrInvalidMsgType
+		return 0, ErrInvalidMsgType
 	}
 
 	if t.Header.MessageLength == 0 {
@@ -23,44 +45,56 @@ func (t *FollowUpMsg) MarshalBinary() ([]byte, error) {
 	}
 
 	if t.Header.MessageLength != HeaderLen+FollowUpPayloadLen {
-		return nil, io.ErrUnexpectedEOF
+		return 0, io.ErrUnexpectedEOF
 	}
 
-	b := make([]byte, HeaderLen+FollowUpPayloadLen)
-
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
 	}
 
-	copy(b[:HeaderLen], headerSlice)
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 
 	// Origin timestamp
-	time2OriginTimestamp(t.PreciseOriginTimestamp, b[HeaderLen:])
+	time2OriginTimestamp(t.PreciseOriginTimestamp, b[HeaderLen:n])
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a FollowUpMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid FollowUpMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are ignored.
 func (t *FollowUpMsg) UnmarshalBinary(b []byte) error {
-	if len(b) < HeaderLen+FollowUpPayloadLen {
-		return io.ErrUnexpectedEOF
-	}
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
+
+// unmarshal decodes a FollowUpMsg accordingly with the options.
+func (t *FollowUpMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, FollowUpMsgType, HeaderLen+FollowUpPayloadLen)
 	if err != nil {
 		return err
 	}
 
+	if err = t.Header.unmarshal(b, o); err != nil {
+		return err
+	}
+
 	if t.Header.MessageType != FollowUpMsgType {
-		return ErrInvalidMsgType
+		return decodeError(FollowUpMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
 	}
 
-	if t.PreciseOriginTimestamp, err = originTimestamp2Time(b[HeaderLen:]); err != nil {
-		return err
+	if t.PreciseOriginTimestamp, err = originTimestamp2Time(b[HeaderLen : HeaderLen+OriginTimestampFullLen]); err != nil {
+		return decodeError(FollowUpMsgType, "preciseOriginTimestamp", HeaderLen, nil, err)
 	}
 
-	return nil
+	return o.trailer(b[HeaderLen+FollowUpPayloadLen:], FollowUpMsgType, HeaderLen+FollowUpPayloadLen)
+}
+
+// Validate checks that a FollowUpMsg conforms to IEEE 1588.
+func (t *FollowUpMsg) Validate() error {
+	return t.Header.validate(FollowUpMsgType, t.MarshalLen(), false)
 }
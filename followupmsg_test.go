@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -91,6 +92,8 @@ func TestUnmarshalFollowUp(t *testing.T) {
 					PortNumber:       2,
 					SequenceID:       55330,
 					LogMessagePeriod: -4,
+					// controlField of Sync
+					Faults: DecodeFaults{WrongControlField: true},
 				},
 				PreciseOriginTimestamp: time.Unix(500, 200),
 			},
@@ -125,7 +128,7 @@ func TestUnmarshalFollowUp(t *testing.T) {
 			m := new(FollowUpMsg)
 			err := m.UnmarshalBinary(tt.b)
 			if err != nil {
-				if want, got := tt.err, err; want != got {
+				if want, got := tt.err, err; !errors.Is(got, want) {
 					t.Fatalf("unexpected error: %v != %v", want, got)
 				}
 
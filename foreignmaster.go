@@ -0,0 +1,140 @@
+package ptp
+
+import "time"
+
+// Qualification of foreign masters, IEEE 1588-2008 9.3.2.4.4 and 9.3.2.5:
+// a foreign master is considered by the best master clock algorithm once
+// ForeignMasterThreshold of its Announce messages were received within
+// ForeignMasterTimeWindow announce intervals.
+const (
+	ForeignMasterTimeWindow = 4
+	ForeignMasterThreshold  = 2
+)
+
+type foreignMasterKey struct {
+	receiver PortIdentity
+	sender   PortIdentity
+}
+
+type foreignMaster struct {
+	announce AnnounceMsg
+	// received holds the receipt times within the window, oldest first
+	received []time.Time
+}
+
+// window returns the time window of the announce interval of the last
+// Announce message, which is not one of the special values.
+func (f *foreignMaster) window() time.Duration {
+	return ForeignMasterTimeWindow * f.announce.LogMessagePeriod.Duration()
+}
+
+// age drops the receipt times that left the window at now.
+func (f *foreignMaster) age(now time.Time) {
+	start := now.Add(-f.window())
+
+	i := 0
+	for i < len(f.received) && !f.received[i].After(start) {
+		i++
+	}
+	f.received = f.received[i:]
+}
+
+// ForeignMasterTable records the Announce messages received by the ports of
+// a clock and selects the best qualified foreign master of each port,
+// Erbest. Entries are aged out by the announce interval carried in
+// logMessageInterval of the messages.
+//
+// A ForeignMasterTable is not safe for concurrent use.
+type ForeignMasterTable struct {
+	// Clock runs the windows of the entries, time.Now if nil.
+	Clock Clock
+
+	clockIdentity uint64
+	masters       map[foreignMasterKey]*foreignMaster
+}
+
+// NewForeignMasterTable returns an empty table of the clock clockIdentity,
+// aging entries with the time of clock.
+func NewForeignMasterTable(clockIdentity uint64, clock Clock) *ForeignMasterTable {
+	return &ForeignMasterTable{
+		Clock:         clock,
+		clockIdentity: clockIdentity,
+		masters:       make(map[foreignMasterKey]*foreignMaster),
+	}
+}
+
+// Add records m received on port receiver and reports whether its sender is
+// qualified. Messages sent by the clock itself, messages with stepsRemoved
+// of 255 or more and messages with a special logMessageInterval, which has
+// no window, are not recorded.
+func (t *ForeignMasterTable) Add(m *AnnounceMsg, receiver PortIdentity) bool {
+	sender := m.SourcePortIdentity()
+	if sender.ClockIdentity == t.clockIdentity || m.StepsRemoved > maxStepsRemoved || m.LogMessagePeriod.Special() {
+		return false
+	}
+
+	key := foreignMasterKey{receiver, sender}
+	f, ok := t.masters[key]
+	if !ok {
+		f = &foreignMaster{}
+		t.masters[key] = f
+	}
+
+	now := timerNow(t.Clock)
+	f.announce = *m
+	f.age(now)
+	f.received = append(f.received, now)
+
+	return len(f.received) >= ForeignMasterThreshold
+}
+
+// Erbest returns the data set of the best qualified foreign master of port
+// receiver, nil if there is none. Stale entries are removed.
+func (t *ForeignMasterTable) Erbest(receiver PortIdentity) *ComparisonDataset {
+	now := timerNow(t.Clock)
+
+	var best *ComparisonDataset
+	for key, f := range t.masters {
+		if key.receiver != receiver {
+			continue
+		}
+
+		f.age(now)
+		if len(f.received) == 0 {
+			delete(t.masters, key)
+			continue
+		}
+
+		if len(f.received) < ForeignMasterThreshold {
+			continue
+		}
+
+		d := NewComparisonDataset(&f.announce, receiver)
+		if best == nil || betterThan(d, best) {
+			best = &d
+		}
+	}
+
+	return best
+}
+
+// Announce returns the last Announce message of the foreign master sender
+// received on port receiver.
+func (t *ForeignMasterTable) Announce(receiver, sender PortIdentity) (AnnounceMsg, bool) {
+	f, ok := t.masters[foreignMasterKey{receiver, sender}]
+	if !ok {
+		return AnnounceMsg{}, false
+	}
+
+	return f.announce, true
+}
+
+// Clear removes the foreign masters of port receiver, for example when the
+// port leaves the states that receive Announce messages.
+func (t *ForeignMasterTable) Clear(receiver PortIdentity) {
+	for key := range t.masters {
+		if key.receiver == receiver {
+			delete(t.masters, key)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForeignMasterTable(t *testing.T) {
+	const local uint64 = 0x000af7fffe000010
+
+	port1 := PortIdentity{ClockIdentity: local, PortNumber: 1}
+	port2 := PortIdentity{ClockIdentity: local, PortNumber: 2}
+
+	announce := func(sender uint64, class ClockClassType) *AnnounceMsg {
+		return &AnnounceMsg{
+			Header: Header{
+				MessageType:   AnnounceMsgType,
+				ClockIdentity: sender,
+				PortNumber:    1,
+				// 1s announce interval, 4s window
+				LogMessagePeriod: 0,
+			},
+			GMPriority1:    128,
+			GMClockQuality: ClockQuality{ClockClass: class},
+			GMPriority2:    128,
+			GMIdentity:     sender,
+		}
+	}
+
+	gm := announce(0x20, 6)
+	worse := announce(0x30, 7)
+
+	special := announce(0x20, 6)
+	special.LogMessagePeriod = LogIntervalInitial
+
+	type step struct {
+		desc      string
+		wait      time.Duration
+		m         *AnnounceMsg
+		receiver  PortIdentity
+		qualified bool
+		// best is the grandmaster of Erbest of port1, 0 if none
+		best uint64
+	}
+
+	var tests = []struct {
+		desc  string
+		steps []step
+	}{
+		{
+			desc: "Qualified on the second Announce",
+			steps: []step{
+				{desc: "First", m: gm, receiver: port1},
+				{desc: "Second", wait: time.Second, m: gm, receiver: port1, qualified: true, best: 0x20},
+				{desc: "Third", wait: time.Second, m: gm, receiver: port1, qualified: true, best: 0x20},
+			},
+		},
+		{
+			desc: "Stray Announce",
+			steps: []step{
+				{desc: "First", m: gm, receiver: port1},
+				{desc: "Outside the window", wait: 4 * time.Second, m: gm, receiver: port1},
+				{desc: "Inside the window", wait: 3 * time.Second, m: gm, receiver: port1, qualified: true, best: 0x20},
+			},
+		},
+		{
+			desc: "Aged out",
+			steps: []step{
+				{desc: "First", m: gm, receiver: port1},
+				{desc: "Second", wait: time.Second, m: gm, receiver: port1, qualified: true, best: 0x20},
+				{desc: "First leaves the window", wait: 3 * time.Second},
+				{desc: "Second leaves the window", wait: time.Second},
+			},
+		},
+		{
+			desc: "Best qualified",
+			steps: []step{
+				{desc: "Better", m: gm, receiver: port1},
+				{desc: "Worse", m: worse, receiver: port1},
+				{desc: "Worse qualified", wait: time.Second, m: worse, receiver: port1, qualified: true, best: 0x30},
+				{desc: "Better qualified", m: gm, receiver: port1, qualified: true, best: 0x20},
+			},
+		},
+		{
+			desc: "Ports kept apart",
+			steps: []step{
+				{desc: "Port 1", m: gm, receiver: port1},
+				{desc: "Port 2", m: gm, receiver: port2},
+				{desc: "Port 2 qualified", wait: time.Second, m: gm, receiver: port2, qualified: true},
+			},
+		},
+		{
+			desc: "Own Announce",
+			steps: []step{
+				{desc: "First", m: announce(local, 6), receiver: port1},
+				{desc: "Second", m: announce(local, 6), receiver: port1},
+			},
+		},
+		{
+			desc: "Special announce interval",
+			steps: []step{
+				{desc: "First", m: gm, receiver: port1},
+				{desc: "Second", wait: time.Second, m: gm, receiver: port1, qualified: true, best: 0x20},
+				{desc: "Not recorded", m: special, receiver: port1, best: 0x20},
+				{desc: "Window of the recorded interval", wait: 2 * time.Second, best: 0x20},
+			},
+		},
+		{
+			desc: "Too many steps removed",
+			steps: []step{
+				{desc: "First", m: &AnnounceMsg{GMIdentity: 0x20, StepsRemoved: 255}, receiver: port1},
+				{desc: "Second", m: &AnnounceMsg{GMIdentity: 0x20, StepsRemoved: 255}, receiver: port1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			clock := NewSimClock(time.Unix(1000, 0), 0, 0, 1)
+			ft := NewForeignMasterTable(local, clock)
+
+			for _, s := range tt.steps {
+				clock.Advance(s.wait)
+
+				if s.m != nil {
+					if want, got := s.qualified, ft.Add(s.m, s.receiver); want != got {
+						t.Fatalf("%s: unexpected qualification: %v != %v", s.desc, want, got)
+					}
+				}
+
+				var best uint64
+				if d := ft.Erbest(port1); d != nil {
+					best = d.GMIdentity
+				}
+
+				if want, got := s.best, best; want != got {
+					t.Fatalf("%s: unexpected Erbest: %#x != %#x", s.desc, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestForeignMasterTableClear(t *testing.T) {
+	port1 := PortIdentity{ClockIdentity: 0x10, PortNumber: 1}
+	sender := PortIdentity{ClockIdentity: 0x20, PortNumber: 1}
+	m := &AnnounceMsg{Header: Header{ClockIdentity: 0x20, PortNumber: 1}, GMIdentity: 0x20, StepsRemoved: 1}
+
+	ft := NewForeignMasterTable(0x10, nil)
+	ft.Add(m, port1)
+
+	if got, ok := ft.Announce(port1, sender); !ok || got.StepsRemoved != 1 {
+		t.Fatalf("unexpected Announce: %v, %v", got, ok)
+	}
+
+	ft.Clear(port1)
+
+	if _, ok := ft.Announce(port1, sender); ok {
+		t.Fatal("unexpected Announce after clear")
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/toxxin/go-ptp
+
+go 1.17
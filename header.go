@@ -3,6 +3,7 @@ package ptp
 import (
 	"encoding/binary"
 	"io"
+	"time"
 )
 
 // ProtoVersion is PTP protocol version
@@ -99,20 +100,58 @@ type Header struct {
 	MessageType      MsgType
 	MessageLength    uint16
 	VersionPTP       ProtoVersion
+	DomainNumber     uint8
 	CorrectionNs     uint64
 	CorrectionSubNs  uint16
 	ClockIdentity    uint64
 	PortNumber       uint16
 	SequenceID       uint16
-	LogMessagePeriod int8
+	LogMessagePeriod LogInterval
+
+	// Faults of a decoded message, ignored by MarshalBinary
+	Faults DecodeFaults
+}
+
+// SourcePortIdentity returns the identity of the port sending the message.
+func (h *Header) SourcePortIdentity() PortIdentity {
+	return PortIdentity{h.ClockIdentity, h.PortNumber}
+}
+
+// Correction returns correctionField, rounded down to nanoseconds.
+func (h *Header) Correction() time.Duration {
+	return time.Duration(h.correctionField() >> 16)
+}
+
+// SetCorrection sets correctionField to d.
+func (h *Header) SetCorrection(d time.Duration) {
+	h.setCorrectionField(int64(d) << 16)
 }
 
 // MarshalBinary allocates a byte slice and marshals a Header into binary form.
 func (h *Header) MarshalBinary() ([]byte, error) {
+	b := make([]byte, HeaderLen)
+
+	if _, err := h.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// AppendBinary appends the binary form of a Header to b.
+func (h *Header) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, HeaderLen, h.MarshalTo)
+}
+
+// MarshalTo marshals a Header into b and returns the number of bytes written.
+// If b is too short, io.ErrShortBuffer is returned.
+func (h *Header) MarshalTo(b []byte) (int, error) {
+	if len(b) < HeaderLen {
+		return 0, io.ErrShortBuffer
+	}
 
 	var correction uint64
 
-	b := make([]byte, HeaderLen)
 	offset := 0
 
 	// Transport specific, messageId
@@ -129,11 +168,12 @@ func (h *Header) MarshalBinary() ([]byte, error) {
 	b[offset] = byte(h.MessageLength)
 	offset++
 
-	// Subdomain number
-	b[offset] = 0x0
+	// Domain number
+	b[offset] = h.DomainNumber
 	offset++
 
-	// Skip reserved byte
+	// Reserved byte
+	b[offset] = 0x0
 	offset++
 
 	flags := (&h.Flags).MarshalBinary()
@@ -141,12 +181,14 @@ func (h *Header) MarshalBinary() ([]byte, error) {
 	binary.BigEndian.PutUint16(b[offset:offset+FlagsLen], flags)
 	offset += FlagsLen
 
-	// Correction Ns & SubNs
-	correction = (h.CorrectionNs << 2) | (uint64)(h.CorrectionSubNs)
+	// Correction Ns & SubNs, nanoseconds multiplied by 2^16 as decoded by
+	// UnmarshalBinary
+	correction = (h.CorrectionNs << 16) | (uint64)(h.CorrectionSubNs)
 	binary.BigEndian.PutUint64(b[offset:offset+CorrectionFullLen], correction)
 	offset += CorrectionFullLen
 
-	// Skip 4 reserved bytes
+	// 4 reserved bytes
+	zero(b[offset : offset+4])
 	offset += 4
 
 	// Clock identity
@@ -161,27 +203,13 @@ func (h *Header) MarshalBinary() ([]byte, error) {
 	binary.BigEndian.PutUint16(b[offset:offset+SequenceIDLen], h.SequenceID)
 	offset += SequenceIDLen
 
-	var msgCtrl MsgCtrlType
-	switch h.MessageType {
-	case (SyncMsgType):
-		msgCtrl = SyncMsgCtrlType
-	case (DelayReqMsgType):
-		msgCtrl = DelayReqMsgCtrlType
-	case (FollowUpMsgType):
-		msgCtrl = FollowUpMsgCtrlType
-	case (DelayRespMsgType):
-		msgCtrl = DelayRespMsgCtrlType
-	default:
-		msgCtrl = OtherMsgCtrlType
-	}
-
-	b[offset] = byte(msgCtrl)
+	b[offset] = byte(controlField(h.MessageType))
 	offset++
 
 	b[offset] = (byte)(h.LogMessagePeriod)
 	offset++
 
-	return b, nil
+	return offset, nil
 }
 
 func isValidMsgType(msgtype MsgType) bool {
@@ -205,36 +233,92 @@ func isValidMsgType(msgtype MsgType) bool {
 // UnmarshalBinary unmarshals a byte slice into a Header.
 func (h *Header) UnmarshalBinary(b []byte) error {
 	if len(b) != HeaderLen {
-		return io.ErrUnexpectedEOF
+		return decodeError(peekMsgType(b), "", len(b), nil, io.ErrUnexpectedEOF)
+	}
+
+	return h.unmarshal(b, &exactDecodeOptions)
+}
+
+// unmarshal decodes the header at the start of b.
+func (h *Header) unmarshal(b []byte, o *DecodeOptions) error {
+	if len(b) < HeaderLen {
+		return decodeError(peekMsgType(b), "", len(b), nil, io.ErrUnexpectedEOF)
 	}
 
+	h.Faults = DecodeFaults{}
+
 	h.MessageType = MsgType(b[0] & 0x0f)
 	if !isValidMsgType(h.MessageType) {
-		return ErrInvalidMsgType
+		return decodeError(h.MessageType, "messageType", 0, h.MessageType, ErrInvalidMsgType)
 	}
 
 	// TODO: Add implementation another versions
 	h.VersionPTP = ProtoVersion(0xf & b[1])
 	if h.VersionPTP != Version2 {
-		return ErrUnsupportedVersion
+		return decodeError(h.MessageType, "versionPTP", 1, h.VersionPTP, ErrUnsupportedVersion)
 	}
 
 	h.MessageLength = binary.BigEndian.Uint16(b[2:4])
+	h.DomainNumber = b[4]
 
 	h.Flags.UnmarshalBinary(b[6:8])
 
 	// Correct Ns & SubNs
-	tmpSlice := make([]byte, 8)
-	copy(tmpSlice[2:], b[8:14])
-
-	h.CorrectionNs = binary.BigEndian.Uint64(tmpSlice)
+	h.CorrectionNs = uint48(b[8:14])
 	h.CorrectionSubNs = binary.BigEndian.Uint16(b[14:16])
 
 	h.ClockIdentity = binary.BigEndian.Uint64(b[20:28])
 	h.PortNumber = binary.BigEndian.Uint16(b[28:30])
 
 	h.SequenceID = binary.BigEndian.Uint16(b[30:32])
-	h.LogMessagePeriod = int8(b[33])
+	h.LogMessagePeriod = LogInterval(b[33])
+
+	// controlField is deprecated, MarshalBinary derives it from messageType
+	if ctrl := MsgCtrlType(b[32]); ctrl != controlField(h.MessageType) {
+		if o.RejectControlField {
+			return decodeError(h.MessageType, "controlField", 32, ctrl, ErrInvalidControlField)
+		}
+		h.Faults.ControlField, h.Faults.WrongControlField = ctrl, true
+	}
 
-	return nil
+	if err := o.reserved(h, b[5:6], 5); err != nil {
+		return err
+	}
+
+	if flags := binary.BigEndian.Uint16(b[6:8]); flags&reservedFlagsMask != 0 {
+		if o.RejectReserved {
+			return decodeError(h.MessageType, "flagField", 6, flags, ErrReservedNotZero)
+		}
+		h.Faults.reserved(6, flags&reservedFlagsMask)
+	}
+
+	return o.reserved(h, b[16:20], 16)
+}
+
+// controlField returns the controlField value of messages of type msgType.
+func controlField(msgType MsgType) MsgCtrlType {
+	switch msgType {
+	case SyncMsgType:
+		return SyncMsgCtrlType
+	case DelayReqMsgType:
+		return DelayReqMsgCtrlType
+	case FollowUpMsgType:
+		return FollowUpMsgCtrlType
+	case DelayRespMsgType:
+		return DelayRespMsgCtrlType
+	case MgmtMsgType:
+		return MgmtMsgCtrlType
+	default:
+		return OtherMsgCtrlType
+	}
+}
+
+// peekMsgType returns the messageType of the message in b, or zero if b is
+// empty.
+func peekMsgType(b []byte) MsgType {
+	if len(b) == 0 {
+		return 0
+	}
+
+	return MsgType(b[0] & 0x0f)
 }
@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -46,6 +47,24 @@ func TestMarshalHeader(t *testing.T) {
 				0x0, 0x0, 0x0, 0x0,
 				0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x5, 0xfc}),
 		},
+		{
+			desc: "Correction field",
+			h: &Header{
+				MessageType:      FollowUpMsgType,
+				MessageLength:    44,
+				VersionPTP:       Version2,
+				CorrectionNs:     0x0102030405,
+				CorrectionSubNs:  0x8000,
+				ClockIdentity:    0x000af7fffe42a753,
+				PortNumber:       2,
+				SequenceID:       55330,
+				LogMessagePeriod: -4,
+			},
+			b: []byte{0x8, 0x2, 0x0, 0x2c, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x80, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x2, 0xfc},
+		},
 	}
 
 	for _, tt := range tests {
@@ -66,6 +85,50 @@ func TestMarshalHeader(t *testing.T) {
 	}
 }
 
+func TestHeaderCorrectionField(t *testing.T) {
+	// correctionField is in nanoseconds multiplied by 2^16
+	var tests = []struct {
+		desc  string
+		ns    uint64
+		subNs uint16
+		b     []byte
+	}{
+		{desc: "Zero", b: []byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}},
+		{desc: "1ns", ns: 1, b: []byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0}},
+		{desc: "Half nanosecond", subNs: 0x8000, b: []byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x80, 0x0}},
+		{desc: "1.5ms", ns: 1500000, subNs: 0x8000, b: []byte{0x0, 0x0, 0x0, 0x16, 0xe3, 0x60, 0x80, 0x0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			h := Header{
+				MessageType:     SyncMsgType,
+				VersionPTP:      Version2,
+				CorrectionNs:    tt.ns,
+				CorrectionSubNs: tt.subNs,
+			}
+
+			b, err := h.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := tt.b, b[8:16]; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected correctionField:\n- want: %#v\n-  got: %#v", want, got)
+			}
+
+			var got Header
+			if err := got.UnmarshalBinary(b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.CorrectionNs != tt.ns || got.CorrectionSubNs != tt.subNs {
+				t.Fatalf("unexpected correction: %d+%#x != %d+%#x", got.CorrectionNs, got.CorrectionSubNs, tt.ns, tt.subNs)
+			}
+		})
+	}
+}
+
 func TestUnmarshalHeader(t *testing.T) {
 
 	var tests = []struct {
@@ -136,7 +199,7 @@ func TestUnmarshalHeader(t *testing.T) {
 			h := new(Header)
 			err := h.UnmarshalBinary(tt.b)
 			if err != nil {
-				if want, got := tt.err, err; want != got {
+				if want, got := tt.err, err; !errors.Is(got, want) {
 					t.Fatalf("unexpected error: %v != %v", want, got)
 				}
 
@@ -179,3 +242,66 @@ func BenchmarkMarshalHeader(b *testing.B) {
 		h.MarshalBinary()
 	}
 }
+
+func TestHeaderDomainNumber(t *testing.T) {
+	for _, domain := range []uint8{0, 24, 127} {
+		h := Header{MessageType: AnnounceMsgType, VersionPTP: Version2, DomainNumber: domain}
+
+		b, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := domain, b[4]; want != got {
+			t.Fatalf("unexpected domainNumber byte: %v != %v", want, got)
+		}
+
+		var got Header
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := domain, got.DomainNumber; want != got {
+			t.Fatalf("unexpected DomainNumber: %v != %v", want, got)
+		}
+	}
+}
+
+func TestUnmarshalHeaderComparable(t *testing.T) {
+	b := []byte{0x0, 0x2, 0x0, 0x2c, 0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0x0,
+		0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x0, 0xfc}
+
+	var clean Header
+	if err := clean.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Headers decoded from the same faults compare equal
+	faulty := withByte(withByte(b, 32, 0x5), 17, 0x1)
+	var want, got Header
+	if err := want.UnmarshalBinary(faulty); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := got.UnmarshalBinary(faulty); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want != got {
+		t.Fatalf("unexpected Header:\n- want: %#v\n-  got: %#v", want, got)
+	}
+
+	if want, got := (DecodeFaults{ControlField: 0x5, WrongControlField: true, ReservedOffset: 17, Reserved: 0x1}), got.Faults; want != got {
+		t.Fatalf("unexpected Faults:\n- want: %#v\n-  got: %#v", want, got)
+	}
+
+	// Decoding a well formed header clears the faults
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := clean; want != got {
+		t.Fatalf("unexpected Header:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
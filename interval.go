@@ -0,0 +1,66 @@
+package ptp
+
+import (
+	"math"
+	"time"
+)
+
+// LogInterval is the log base 2 of an interval in seconds, as carried in
+// logMessageInterval and the interval fields of TLVs.
+type LogInterval int8
+
+// Special values of LogInterval. They are not intervals: Duration returns 0
+// for them and Interval reports them as never elapsing.
+const (
+	// LogIntervalStop asks the sender to stop sending the messages
+	LogIntervalStop LogInterval = 126
+	// LogIntervalInitial asks the sender to restore the initial interval. In
+	// logMessageInterval it is sent by messages not sent periodically.
+	LogIntervalInitial LogInterval = 127
+	// LogIntervalNoChange asks the sender to keep the current interval
+	LogIntervalNoChange LogInterval = -128
+)
+
+// maxLogInterval is the largest LogInterval whose interval fits a
+// time.Duration.
+const maxLogInterval LogInterval = 33
+
+// Special reports whether l is one of the special values.
+func (l LogInterval) Special() bool {
+	return l == LogIntervalStop || l == LogIntervalInitial || l == LogIntervalNoChange
+}
+
+// Duration returns the interval 2^l seconds. Intervals too long for a
+// time.Duration are capped to its largest value.
+func (l LogInterval) Duration() time.Duration {
+	switch {
+	case l.Special():
+		return 0
+	case l > maxLogInterval:
+		return math.MaxInt64
+	case l >= 0:
+		return time.Second << uint(l)
+	default:
+		return time.Second >> uint(-l)
+	}
+}
+
+// Interval returns the interval 2^l seconds as Duration does. ok is false for
+// the special values, meaning the messages are not sent periodically.
+func (l LogInterval) Interval() (d time.Duration, ok bool) {
+	if l.Special() {
+		return 0, false
+	}
+
+	return l.Duration(), true
+}
+
+// LogIntervalFromDuration returns the LogInterval closest to d. If d is not
+// positive, ErrInvalidLogInterval is returned.
+func LogIntervalFromDuration(d time.Duration) (LogInterval, error) {
+	if d <= 0 {
+		return 0, ErrInvalidLogInterval
+	}
+
+	return LogInterval(math.Round(math.Log2(d.Seconds()))), nil
+}
@@ -0,0 +1,77 @@
+package ptp
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLogIntervalDuration(t *testing.T) {
+	var tests = []struct {
+		desc string
+		l    LogInterval
+		d    time.Duration
+	}{
+		{desc: "1s", l: 0, d: time.Second},
+		{desc: "2s", l: 1, d: 2 * time.Second},
+		{desc: "125ms", l: -3, d: 125 * time.Millisecond},
+		{desc: "7.8125ms", l: -7, d: 7812500 * time.Nanosecond},
+		{desc: "Largest", l: 33, d: (1 << 33) * time.Second},
+		{desc: "Too long", l: 34, d: math.MaxInt64},
+		{desc: "Too short", l: -31, d: 0},
+		{desc: "Stop", l: LogIntervalStop, d: 0},
+		{desc: "Initial", l: LogIntervalInitial, d: 0},
+		{desc: "No change", l: LogIntervalNoChange, d: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.d, tt.l.Duration(); want != got {
+				t.Fatalf("unexpected duration: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestLogIntervalInterval(t *testing.T) {
+	if d, ok := LogInterval(-3).Interval(); !ok || d != 125*time.Millisecond {
+		t.Fatalf("unexpected interval: %v, %v", d, ok)
+	}
+
+	for _, l := range []LogInterval{LogIntervalStop, LogIntervalInitial, LogIntervalNoChange} {
+		if d, ok := l.Interval(); ok || d != 0 {
+			t.Fatalf("unexpected interval of %d: %v, %v", l, d, ok)
+		}
+	}
+}
+
+func TestLogIntervalFromDuration(t *testing.T) {
+	var tests = []struct {
+		desc string
+		d    time.Duration
+		l    LogInterval
+		err  error
+	}{
+		{desc: "1s", d: time.Second, l: 0},
+		{desc: "16s", d: 16 * time.Second, l: 4},
+		{desc: "62.5ms", d: 62500 * time.Microsecond, l: -4},
+		{desc: "Rounded up", d: 1500 * time.Millisecond, l: 1},
+		{desc: "Rounded down", d: 1400 * time.Millisecond, l: 0},
+		{desc: "1ns", d: time.Nanosecond, l: -30},
+		{desc: "Zero", d: 0, err: ErrInvalidLogInterval},
+		{desc: "Negative", d: -time.Second, err: ErrInvalidLogInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			l, err := LogIntervalFromDuration(tt.d)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+
+			if want, got := tt.l, l; want != got {
+				t.Fatalf("unexpected interval: %v != %v", want, got)
+			}
+		})
+	}
+}
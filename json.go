@@ -0,0 +1,695 @@
+package ptp
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JSON forms of messages and TLVs. Field names are the ones of IEEE 1588 and
+// the standards defining the TLVs.
+
+// clockIdentity is a clockIdentity formatted as in "000af7.fffe.42a753"
+type clockIdentity uint64
+
+func (c clockIdentity) MarshalText() ([]byte, error) {
+	return []byte(formatClockIdentity(uint64(c))), nil
+}
+
+func (c *clockIdentity) UnmarshalText(b []byte) error {
+	id, err := parseClockIdentity(string(b))
+	*c = clockIdentity(id)
+	return err
+}
+
+type portIdentityJSON struct {
+	ClockIdentity clockIdentity `json:"clockIdentity"`
+	PortNumber    uint16        `json:"portNumber"`
+}
+
+// timestampJSON is a Timestamp of the PTP timescale
+type timestampJSON struct {
+	SecondsField     int64  `json:"secondsField"`
+	NanosecondsField uint32 `json:"nanosecondsField"`
+}
+
+func newTimestampJSON(t time.Time) timestampJSON {
+	return timestampJSON{t.Unix(), uint32(t.Nanosecond())}
+}
+
+func (t timestampJSON) time() time.Time {
+	return time.Unix(t.SecondsField, int64(t.NanosecondsField))
+}
+
+// MarshalJSON returns the names of the flags set as a JSON array.
+func (f Flags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.names())
+}
+
+// UnmarshalJSON sets the flags named in a JSON array.
+func (f *Flags) UnmarshalJSON(b []byte) error {
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+	return f.setNames(names)
+}
+
+type headerJSON struct {
+	MessageType        MsgType          `json:"messageType"`
+	VersionPTP         ProtoVersion     `json:"versionPTP"`
+	MessageLength      uint16           `json:"messageLength"`
+	DomainNumber       uint8            `json:"domainNumber"`
+	FlagField          Flags            `json:"flagField"`
+	CorrectionField    int64            `json:"correctionField"`
+	SourcePortIdentity portIdentityJSON `json:"sourcePortIdentity"`
+	SequenceID         uint16           `json:"sequenceId"`
+	LogMessageInterval LogInterval      `json:"logMessageInterval"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h Header) MarshalJSON() ([]byte, error) {
+	return json.Marshal(headerJSON{
+		MessageType:        h.MessageType,
+		VersionPTP:         h.VersionPTP,
+		MessageLength:      h.MessageLength,
+		DomainNumber:       h.DomainNumber,
+		FlagField:          h.Flags,
+		CorrectionField:    h.correctionField(),
+		SourcePortIdentity: portIdentityJSON{clockIdentity(h.ClockIdentity), h.PortNumber},
+		SequenceID:         h.SequenceID,
+		LogMessageInterval: h.LogMessagePeriod,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *Header) UnmarshalJSON(b []byte) error {
+	var j headerJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*h = Header{
+		Flags:            j.FlagField,
+		MessageType:      j.MessageType,
+		MessageLength:    j.MessageLength,
+		VersionPTP:       j.VersionPTP,
+		DomainNumber:     j.DomainNumber,
+		ClockIdentity:    uint64(j.SourcePortIdentity.ClockIdentity),
+		PortNumber:       j.SourcePortIdentity.PortNumber,
+		SequenceID:       j.SequenceID,
+		LogMessagePeriod: j.LogMessageInterval,
+	}
+	h.setCorrectionField(j.CorrectionField)
+
+	return nil
+}
+
+type clockQualityJSON struct {
+	ClockClass    ClockClassType    `json:"clockClass"`
+	ClockAccuracy ClockAccuracyType `json:"clockAccuracy"`
+	ClockVariance uint16            `json:"offsetScaledLogVariance"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p ClockQuality) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clockQualityJSON(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *ClockQuality) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*clockQualityJSON)(p))
+}
+
+type defaultDataSetTlvJSON struct {
+	TwoStepFlag   bool          `json:"twoStepFlag"`
+	SlaveOnly     bool          `json:"slaveOnly"`
+	NumberPorts   uint16        `json:"numberPorts"`
+	Priority1     uint8         `json:"priority1"`
+	ClockQuality  ClockQuality  `json:"clockQuality"`
+	Priority2     uint8         `json:"priority2"`
+	ClockIdentity clockIdentity `json:"clockIdentity"`
+	DomainNumber  uint8         `json:"domainNumber"`
+}
+
+// MarshalJSON implements json.Marshaler. It is defined so that the method of
+// the embedded ClockQuality doesn't drop the other fields.
+func (p DefaultDataSetTlv) MarshalJSON() ([]byte, error) {
+	return json.Marshal(defaultDataSetTlvJSON{
+		TwoStepFlag:   p.TSC,
+		SlaveOnly:     p.SO,
+		NumberPorts:   p.NumberPorts,
+		Priority1:     p.Priority1,
+		ClockQuality:  p.ClockQuality,
+		Priority2:     p.Priority2,
+		ClockIdentity: clockIdentity(p.ClockIdentity),
+		DomainNumber:  p.DomainNumber,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *DefaultDataSetTlv) UnmarshalJSON(b []byte) error {
+	var j defaultDataSetTlvJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*p = DefaultDataSetTlv{
+		TSC:           j.TwoStepFlag,
+		SO:            j.SlaveOnly,
+		NumberPorts:   j.NumberPorts,
+		Priority1:     j.Priority1,
+		ClockQuality:  j.ClockQuality,
+		Priority2:     j.Priority2,
+		ClockIdentity: uint64(j.ClockIdentity),
+		DomainNumber:  j.DomainNumber,
+	}
+
+	return nil
+}
+
+type uScaledNsJSON struct {
+	NanosecondsMsb        uint16 `json:"nanosecondsMsb"`
+	NanosecondsLsb        uint64 `json:"nanosecondsLsb"`
+	FractionalNanoseconds uint16 `json:"fractionalNanoseconds"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p UScaledNs) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uScaledNsJSON{
+		NanosecondsMsb:        uint16(uint32(p.ms) >> 16),
+		NanosecondsLsb:        uint64(uint16(p.ms))<<48 | p.ls>>16,
+		FractionalNanoseconds: uint16(p.ls),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *UScaledNs) UnmarshalJSON(b []byte) error {
+	var j uScaledNsJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	p.ms = int32(uint32(j.NanosecondsMsb)<<16 | uint32(j.NanosecondsLsb>>48))
+	p.ls = j.NanosecondsLsb<<16 | uint64(j.FractionalNanoseconds)
+
+	return nil
+}
+
+type pathTraceTlvJSON struct {
+	PathSequence []clockIdentity `json:"pathSequence"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PathTraceTlv) MarshalJSON() ([]byte, error) {
+	j := pathTraceTlvJSON{PathSequence: make([]clockIdentity, len(p.pathSequence))}
+	for i, id := range p.pathSequence {
+		j.PathSequence[i] = clockIdentity(id)
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PathTraceTlv) UnmarshalJSON(b []byte) error {
+	var j pathTraceTlvJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	p.pathSequence = nil
+	for _, id := range j.PathSequence {
+		p.pathSequence = append(p.pathSequence, uint64(id))
+	}
+
+	return nil
+}
+
+type intervalRequestTlvJSON struct {
+	LinkDelayInterval        LogInterval `json:"linkDelayInterval"`
+	TimeSyncInterval         LogInterval `json:"timeSyncInterval"`
+	AnnounceInterval         LogInterval `json:"announceInterval"`
+	ComputeNeighborRateRatio bool        `json:"computeNeighborRateRatio"`
+	ComputeNeighborPropDelay bool        `json:"computeNeighborPropDelay"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p IntervalRequestTlv) MarshalJSON() ([]byte, error) {
+	return json.Marshal(intervalRequestTlvJSON(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *IntervalRequestTlv) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*intervalRequestTlvJSON)(p))
+}
+
+type followUpTlvJSON struct {
+	CumulativeScaledRateOffset int32     `json:"cumulativeScaledRateOffset"`
+	GmTimeBaseIndicator        uint16    `json:"gmTimeBaseIndicator"`
+	LastGmPhaseChange          UScaledNs `json:"lastGmPhaseChange"`
+	ScaledLastGmFreqChange     int32     `json:"scaledLastGmFreqChange"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p FollowUpTlv) MarshalJSON() ([]byte, error) {
+	return json.Marshal(followUpTlvJSON(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *FollowUpTlv) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*followUpTlvJSON)(p))
+}
+
+type csnTlvJSON struct {
+	UpstreamTxTime    UScaledNs `json:"upstreamTxTime"`
+	NeighborRateRatio int32     `json:"neighborRateRatio"`
+	NeighborPropDelay UScaledNs `json:"neighborPropDelay"`
+	DelayAsymmetry    UScaledNs `json:"delayAsymmetry"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p CsnTlv) MarshalJSON() ([]byte, error) {
+	return json.Marshal(csnTlvJSON(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *CsnTlv) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*csnTlvJSON)(p))
+}
+
+type powerProfileTlvJSON struct {
+	GrandmasterID       uint16 `json:"grandmasterID"`
+	TotalTimeInaccuracy uint32 `json:"totalTimeInaccuracy"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PowerProfileTlv) MarshalJSON() ([]byte, error) {
+	return json.Marshal(powerProfileTlvJSON(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PowerProfileTlv) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*powerProfileTlvJSON)(p))
+}
+
+type powerProfile2011TlvJSON struct {
+	GrandmasterID             uint16 `json:"grandmasterID"`
+	GrandmasterTimeInaccuracy uint32 `json:"grandmasterTimeInaccuracy"`
+	NetworkTimeInaccuracy     uint32 `json:"networkTimeInaccuracy"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PowerProfile2011Tlv) MarshalJSON() ([]byte, error) {
+	return json.Marshal(powerProfile2011TlvJSON(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PowerProfile2011Tlv) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*powerProfile2011TlvJSON)(p))
+}
+
+type frameRateJSON struct {
+	Numerator   uint32 `json:"numerator"`
+	Denominator uint32 `json:"denominator"`
+}
+
+// smpteSyncTlvJSON holds offsets and jumps in seconds, and times in seconds
+// of the PTP timescale.
+type smpteSyncTlvJSON struct {
+	DefaultSystemFrameRate      frameRateJSON           `json:"defaultSystemFrameRate"`
+	MasterLockingStatus         MasterLockingStatusType `json:"masterLockingStatus"`
+	DropFrame                   bool                    `json:"dropFrame"`
+	ColorFrameIdentification    bool                    `json:"colorFrameIdentification"`
+	CurrentLocalOffset          int32                   `json:"currentLocalOffset"`
+	JumpSeconds                 int32                   `json:"jumpSeconds"`
+	TimeOfNextJump              int64                   `json:"timeOfNextJump"`
+	TimeOfNextJam               int64                   `json:"timeOfNextJam"`
+	TimeOfPreviousJam           int64                   `json:"timeOfPreviousJam"`
+	PreviousJamLocalOffset      int32                   `json:"previousJamLocalOffset"`
+	CurrentDaylightSaving       bool                    `json:"currentDaylightSaving"`
+	DaylightSavingAtNextJump    bool                    `json:"daylightSavingAtNextJump"`
+	DaylightSavingAtPreviousJam bool                    `json:"daylightSavingAtPreviousJam"`
+	LeapSecondJump              bool                    `json:"leapSecondJump"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p SmpteSyncTlv) MarshalJSON() ([]byte, error) {
+	return json.Marshal(smpteSyncTlvJSON{
+		DefaultSystemFrameRate:      frameRateJSON(p.DefaultSystemFrameRate),
+		MasterLockingStatus:         p.MasterLockingStatus,
+		DropFrame:                   p.DropFrame,
+		ColorFrameIdentification:    p.ColorFrameIdentification,
+		CurrentLocalOffset:          int32(p.CurrentLocalOffset / time.Second),
+		JumpSeconds:                 int32(p.JumpSeconds / time.Second),
+		TimeOfNextJump:              p.TimeOfNextJump.Unix(),
+		TimeOfNextJam:               p.TimeOfNextJam.Unix(),
+		TimeOfPreviousJam:           p.TimeOfPreviousJam.Unix(),
+		PreviousJamLocalOffset:      int32(p.PreviousJamLocalOffset / time.Second),
+		CurrentDaylightSaving:       p.CurrentDaylightSaving,
+		DaylightSavingAtNextJump:    p.DaylightSavingAtNextJump,
+		DaylightSavingAtPreviousJam: p.DaylightSavingAtPreviousJam,
+		LeapSecondJump:              p.LeapSecondJump,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *SmpteSyncTlv) UnmarshalJSON(b []byte) error {
+	var j smpteSyncTlvJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*p = SmpteSyncTlv{
+		DefaultSystemFrameRate:      FrameRate(j.DefaultSystemFrameRate),
+		MasterLockingStatus:         j.MasterLockingStatus,
+		DropFrame:                   j.DropFrame,
+		ColorFrameIdentification:    j.ColorFrameIdentification,
+		CurrentLocalOffset:          time.Duration(j.CurrentLocalOffset) * time.Second,
+		JumpSeconds:                 time.Duration(j.JumpSeconds) * time.Second,
+		TimeOfNextJump:              time.Unix(j.TimeOfNextJump, 0),
+		TimeOfNextJam:               time.Unix(j.TimeOfNextJam, 0),
+		TimeOfPreviousJam:           time.Unix(j.TimeOfPreviousJam, 0),
+		PreviousJamLocalOffset:      time.Duration(j.PreviousJamLocalOffset) * time.Second,
+		CurrentDaylightSaving:       j.CurrentDaylightSaving,
+		DaylightSavingAtNextJump:    j.DaylightSavingAtNextJump,
+		DaylightSavingAtPreviousJam: j.DaylightSavingAtPreviousJam,
+		LeapSecondJump:              j.LeapSecondJump,
+	}
+
+	return nil
+}
+
+type syncMsgJSON struct {
+	Header          Header        `json:"header"`
+	OriginTimestamp timestampJSON `json:"originTimestamp"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t SyncMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(syncMsgJSON{t.Header, newTimestampJSON(t.OriginTimestamp)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *SyncMsg) UnmarshalJSON(b []byte) error {
+	var j syncMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = SyncMsg{Header: j.Header, OriginTimestamp: j.OriginTimestamp.time()}
+
+	return nil
+}
+
+type delReqMsgJSON struct {
+	Header          Header        `json:"header"`
+	OriginTimestamp timestampJSON `json:"originTimestamp"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t DelReqMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(delReqMsgJSON{t.Header, newTimestampJSON(t.OriginTimestamp)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *DelReqMsg) UnmarshalJSON(b []byte) error {
+	var j delReqMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = DelReqMsg{Header: j.Header, OriginTimestamp: j.OriginTimestamp.time()}
+
+	return nil
+}
+
+type followUpMsgJSON struct {
+	Header                 Header        `json:"header"`
+	PreciseOriginTimestamp timestampJSON `json:"preciseOriginTimestamp"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t FollowUpMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(followUpMsgJSON{t.Header, newTimestampJSON(t.PreciseOriginTimestamp)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *FollowUpMsg) UnmarshalJSON(b []byte) error {
+	var j followUpMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = FollowUpMsg{Header: j.Header, PreciseOriginTimestamp: j.PreciseOriginTimestamp.time()}
+
+	return nil
+}
+
+type delRespMsgJSON struct {
+	Header                 Header           `json:"header"`
+	ReceiveTimestamp       timestampJSON    `json:"receiveTimestamp"`
+	RequestingPortIdentity portIdentityJSON `json:"requestingPortIdentity"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t DelRespMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(delRespMsgJSON{
+		Header:                 t.Header,
+		ReceiveTimestamp:       newTimestampJSON(t.ReceiveTimestamp),
+		RequestingPortIdentity: portIdentityJSON{clockIdentity(t.RequestingPortIdentity), t.RequestingPortID},
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *DelRespMsg) UnmarshalJSON(b []byte) error {
+	var j delRespMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = DelRespMsg{
+		Header:                 j.Header,
+		ReceiveTimestamp:       j.ReceiveTimestamp.time(),
+		RequestingPortIdentity: uint64(j.RequestingPortIdentity.ClockIdentity),
+		RequestingPortID:       j.RequestingPortIdentity.PortNumber,
+	}
+
+	return nil
+}
+
+type pDelReqMsgJSON struct {
+	Header Header `json:"header"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t PDelReqMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pDelReqMsgJSON{t.Header})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *PDelReqMsg) UnmarshalJSON(b []byte) error {
+	var j pDelReqMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = PDelReqMsg{Header: j.Header}
+
+	return nil
+}
+
+type pDelRespMsgJSON struct {
+	Header                  Header           `json:"header"`
+	RequestReceiptTimestamp timestampJSON    `json:"requestReceiptTimestamp"`
+	RequestingPortIdentity  portIdentityJSON `json:"requestingPortIdentity"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t PDelRespMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pDelRespMsgJSON{
+		Header:                  t.Header,
+		RequestReceiptTimestamp: newTimestampJSON(t.ReceiveTimestamp),
+		RequestingPortIdentity:  portIdentityJSON{clockIdentity(t.ClockIdentity), t.PortNumber},
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *PDelRespMsg) UnmarshalJSON(b []byte) error {
+	var j pDelRespMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = PDelRespMsg{
+		Header:           j.Header,
+		ReceiveTimestamp: j.RequestReceiptTimestamp.time(),
+		ClockIdentity:    uint64(j.RequestingPortIdentity.ClockIdentity),
+		PortNumber:       j.RequestingPortIdentity.PortNumber,
+	}
+
+	return nil
+}
+
+type pDelRespFollowUpMsgJSON struct {
+	Header                  Header           `json:"header"`
+	ResponseOriginTimestamp timestampJSON    `json:"responseOriginTimestamp"`
+	RequestingPortIdentity  portIdentityJSON `json:"requestingPortIdentity"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t PDelRespFollowUpMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pDelRespFollowUpMsgJSON{
+		Header:                  t.Header,
+		ResponseOriginTimestamp: newTimestampJSON(t.OriginTimestamp),
+		RequestingPortIdentity:  portIdentityJSON{clockIdentity(t.ClockIdentity), t.PortNumber},
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *PDelRespFollowUpMsg) UnmarshalJSON(b []byte) error {
+	var j pDelRespFollowUpMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = PDelRespFollowUpMsg{
+		Header:          j.Header,
+		OriginTimestamp: j.ResponseOriginTimestamp.time(),
+		ClockIdentity:   uint64(j.RequestingPortIdentity.ClockIdentity),
+		PortNumber:      j.RequestingPortIdentity.PortNumber,
+	}
+
+	return nil
+}
+
+type announceMsgJSON struct {
+	Header                  Header               `json:"header"`
+	CurrentUtcOffset        int16                `json:"currentUtcOffset"`
+	GrandmasterPriority1    uint8                `json:"grandmasterPriority1"`
+	GrandmasterClockQuality ClockQuality         `json:"grandmasterClockQuality"`
+	GrandmasterPriority2    uint8                `json:"grandmasterPriority2"`
+	GrandmasterIdentity     clockIdentity        `json:"grandmasterIdentity"`
+	StepsRemoved            uint16               `json:"stepsRemoved"`
+	TimeSource              TimeSourceType       `json:"timeSource"`
+	PathTrace               *PathTraceTlv        `json:"pathTrace,omitempty"`
+	PowerProfile            *PowerProfileTlv     `json:"powerProfile,omitempty"`
+	PowerProfile2011        *PowerProfile2011Tlv `json:"powerProfile2011,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. An empty PATH_TRACE TLV is omitted.
+func (t AnnounceMsg) MarshalJSON() ([]byte, error) {
+	j := announceMsgJSON{
+		Header:                  t.Header,
+		CurrentUtcOffset:        t.CurrentUtcOffset,
+		GrandmasterPriority1:    t.GMPriority1,
+		GrandmasterClockQuality: t.GMClockQuality,
+		GrandmasterPriority2:    t.GMPriority2,
+		GrandmasterIdentity:     clockIdentity(t.GMIdentity),
+		StepsRemoved:            t.StepsRemoved,
+		TimeSource:              t.TimeSource,
+		PowerProfile:            t.PowerProfile,
+		PowerProfile2011:        t.PowerProfile2011,
+	}
+	if len(t.PathTraceTlv.pathSequence) > 0 {
+		j.PathTrace = &t.PathTraceTlv
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *AnnounceMsg) UnmarshalJSON(b []byte) error {
+	var j announceMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = AnnounceMsg{
+		Header:           j.Header,
+		GMClockQuality:   j.GrandmasterClockQuality,
+		CurrentUtcOffset: j.CurrentUtcOffset,
+		GMPriority1:      j.GrandmasterPriority1,
+		GMPriority2:      j.GrandmasterPriority2,
+		GMIdentity:       uint64(j.GrandmasterIdentity),
+		StepsRemoved:     j.StepsRemoved,
+		TimeSource:       j.TimeSource,
+		PowerProfile:     j.PowerProfile,
+		PowerProfile2011: j.PowerProfile2011,
+	}
+	if j.PathTrace != nil {
+		t.PathTraceTlv = *j.PathTrace
+	}
+
+	return nil
+}
+
+type signalingMsgJSON struct {
+	Header             Header             `json:"header"`
+	TargetPortIdentity portIdentityJSON   `json:"targetPortIdentity"`
+	IntervalRequest    IntervalRequestTlv `json:"intervalRequest"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t SignalingMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(signalingMsgJSON{
+		Header:             t.Header,
+		TargetPortIdentity: portIdentityJSON{clockIdentity(t.ClockIdentity), t.PortNumber},
+		IntervalRequest:    t.IntervalRequestTlv,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *SignalingMsg) UnmarshalJSON(b []byte) error {
+	var j signalingMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = SignalingMsg{
+		Header:             j.Header,
+		ClockIdentity:      uint64(j.TargetPortIdentity.ClockIdentity),
+		PortNumber:         j.TargetPortIdentity.PortNumber,
+		IntervalRequestTlv: j.IntervalRequest,
+	}
+
+	return nil
+}
+
+type mgmtMsgJSON struct {
+	Header               Header           `json:"header"`
+	TargetPortIdentity   portIdentityJSON `json:"targetPortIdentity"`
+	StartingBoundaryHops uint8            `json:"startingBoundaryHops"`
+	BoundaryHops         uint8            `json:"boundaryHops"`
+	ActionField          ActionFiledType  `json:"actionField"`
+	SmpteSync            *SmpteSyncTlv    `json:"smpteSync,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t MgmtMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mgmtMsgJSON{
+		Header:               t.Header,
+		TargetPortIdentity:   portIdentityJSON{clockIdentity(t.ClockIdentity), t.PortNumber},
+		StartingBoundaryHops: t.StartingBoundaryHops,
+		BoundaryHops:         t.BoundaryHops,
+		ActionField:          t.ActionField,
+		SmpteSync:            t.SmpteSync,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *MgmtMsg) UnmarshalJSON(b []byte) error {
+	var j mgmtMsgJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	*t = MgmtMsg{
+		Header:               j.Header,
+		ClockIdentity:        uint64(j.TargetPortIdentity.ClockIdentity),
+		PortNumber:           j.TargetPortIdentity.PortNumber,
+		StartingBoundaryHops: j.StartingBoundaryHops,
+		BoundaryHops:         j.BoundaryHops,
+		ActionField:          j.ActionField,
+		SmpteSync:            j.SmpteSync,
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package ptp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, tt := range marshalers() {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := json.Marshal(tt.m)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			typ := reflect.TypeOf(tt.m)
+			ptr := typ.Kind() == reflect.Ptr
+			if ptr {
+				typ = typ.Elem()
+			}
+
+			v := reflect.New(typ)
+			if err := json.Unmarshal(b, v.Interface()); err != nil {
+				t.Fatalf("unexpected error: %v\n%s", err, b)
+			}
+
+			got := v.Interface().(appendMarshaler)
+			if !ptr {
+				got = v.Elem().Interface().(appendMarshaler)
+			}
+
+			want, _ := tt.m.MarshalBinary()
+			gotBytes, err := got.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !bytes.Equal(want, gotBytes) {
+				t.Fatalf("unexpected bytes after JSON round trip of %s:\n- want: %x\n-  got: %x", b, want, gotBytes)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	m := &SyncMsg{
+		Header: Header{
+			MessageType:      SyncMsgType,
+			MessageLength:    HeaderLen + SyncPayloadLen,
+			VersionPTP:       Version2,
+			Flags:            Flags{TwoSteps: true, Unicast: true},
+			CorrectionNs:     1,
+			CorrectionSubNs:  0x8000,
+			ClockIdentity:    0x000af7fffe42a753,
+			PortNumber:       2,
+			SequenceID:       55330,
+			LogMessagePeriod: -4,
+		},
+		OriginTimestamp: time.Unix(500, 200),
+	}
+
+	want := `{"header":{"messageType":"Sync","versionPTP":2,"messageLength":44,"domainNumber":0,` +
+		`"flagField":["twoStepFlag","unicastFlag"],"correctionField":98304,` +
+		`"sourcePortIdentity":{"clockIdentity":"000af7.fffe.42a753","portNumber":2},` +
+		`"sequenceId":55330,"logMessageInterval":-4},` +
+		`"originTimestamp":{"secondsField":500,"nanosecondsField":200}}`
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := string(b); want != got {
+		t.Fatalf("unexpected JSON:\n- want: %s\n-  got: %s", want, got)
+	}
+
+	got := new(SyncMsg)
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(m, got) {
+		t.Fatalf("unexpected message:\n- want: %#v\n-  got: %#v", m, got)
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    string
+	}{
+		{desc: "Unknown message type", b: `{"header":{"messageType":"Foo"}}`},
+		{desc: "Unknown flag", b: `{"header":{"flagField":["foo"]}}`},
+		{desc: "Invalid clockIdentity", b: `{"header":{"sourcePortIdentity":{"clockIdentity":"000af7fffe42a753"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.b), new(SyncMsg)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestEnumText(t *testing.T) {
+	var tests = []struct {
+		v interface {
+			String() string
+		}
+		text string
+	}{
+		{PDelayRespFollowUpMsgType, "Pdelay_Resp_Follow_Up"},
+		{MsgType(0x4), "0x4"},
+		{PreMaster, "PRE_MASTER"},
+		{TimeSourceInternalOsc, "INTERNAL_OSCILLATOR"},
+		{ClockAccuracy2_5mics, "2.5us"},
+		{ClockAccuracyType(0x17), "0x17"},
+		{LogMinPdelayReqInterval, "LOG_MIN_PDELAY_REQ_INTERVAL"},
+		{ManagementIdType(0xc000), "0xc000"},
+		{Acknowledge, "ACKNOWLEDGE"},
+		{LockingStatusWarmLocking, "WARM_LOCKING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if want, got := tt.text, tt.v.String(); want != got {
+				t.Fatalf("unexpected text: %v != %v", want, got)
+			}
+
+			// Parse the text back into a value of the same type
+			v := reflect.New(reflect.TypeOf(tt.v))
+			b, _ := json.Marshal(tt.text)
+			if err := json.Unmarshal(b, v.Interface()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := tt.v, v.Elem().Interface(); want != got {
+				t.Fatalf("unexpected value: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	m := AnnounceMsg{
+		Header: Header{
+			MessageType:      AnnounceMsgType,
+			ClockIdentity:    0x000af7fffe42a753,
+			PortNumber:       1,
+			SequenceID:       7,
+			LogMessagePeriod: 1,
+			Flags:            Flags{TimeTraceable: true, TimeScale: true},
+		},
+		GMPriority1:      128,
+		GMClockQuality:   ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 0x4e5d},
+		GMPriority2:      128,
+		GMIdentity:       0x000af7fffe42a753,
+		TimeSource:       TimeSourceGPS,
+		CurrentUtcOffset: 37,
+	}
+
+	want := "Announce sourcePortIdentity=000af7.fffe.42a753-1 sequenceId=7 flagField=ptpTimescale|timeTraceable" +
+		" correctionField=0 logMessageInterval=1 currentUtcOffset=37 grandmasterPriority1=128" +
+		" grandmasterClockQuality=6/100ns/0x4e5d grandmasterPriority2=128 grandmasterIdentity=000af7.fffe.42a753" +
+		" stepsRemoved=0 timeSource=GPS"
+
+	if got := m.String(); want != got {
+		t.Fatalf("unexpected string:\n- want: %s\n-  got: %s", want, got)
+	}
+}
+
+func TestDefaultDataSetTlvJSON(t *testing.T) {
+	want := DefaultDataSetTlv{
+		TSC:         true,
+		SO:          true,
+		NumberPorts: 4,
+		Priority1:   127,
+		ClockQuality: ClockQuality{
+			ClockClass:    PrimarySyncRefClass,
+			ClockAccuracy: ClockAccuracy100ns,
+			ClockVariance: 0x4e5d,
+		},
+		Priority2:     129,
+		ClockIdentity: 0x000af7fffe42a753,
+		DomainNumber:  24,
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantJSON := `{"twoStepFlag":true,"slaveOnly":true,"numberPorts":4,"priority1":127,` +
+		`"clockQuality":{"clockClass":6,"clockAccuracy":"100ns","offsetScaledLogVariance":20061},` +
+		`"priority2":129,"clockIdentity":"000af7.fffe.42a753","domainNumber":24}`
+	if got := string(b); wantJSON != got {
+		t.Fatalf("unexpected JSON:\n- want: %s\n-  got: %s", wantJSON, got)
+	}
+
+	var got DefaultDataSetTlv
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want != got {
+		t.Fatalf("unexpected data set:\n- want: %#v\n-  got: %#v", want, got)
+	}
+
+	wantString := "DEFAULT_DATA_SET twoStepFlag=true slaveOnly=true numberPorts=4 priority1=127" +
+		" clockQuality=6/100ns/0x4e5d priority2=129 clockIdentity=000af7.fffe.42a753 domainNumber=24"
+	if got := fmt.Sprint(want); wantString != got {
+		t.Fatalf("unexpected string:\n- want: %s\n-  got: %s", wantString, got)
+	}
+}
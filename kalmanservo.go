@@ -0,0 +1,126 @@
+package ptp
+
+import "time"
+
+// Default noise models of KalmanServo.
+const (
+	// DefaultKalmanMeasurementNoise is the variance of the offsets, in ns²
+	DefaultKalmanMeasurementNoise = 100
+	// DefaultKalmanPhaseNoise is the white frequency noise of the clock, in
+	// ns²/s
+	DefaultKalmanPhaseNoise = 1
+	// DefaultKalmanFrequencyNoise is the random walk frequency noise of the
+	// clock, in ppb²/s
+	DefaultKalmanFrequencyNoise = 0.01
+)
+
+// kalmanFrequencyVariance is the variance of the frequency of the clock
+// before the first sample, in ppb², a frequency error of 1000 ppm
+const kalmanFrequencyVariance = 1e12
+
+// KalmanServo is a servo estimating the offset and frequency error of the
+// clock with a Kalman filter. The clock is modeled by its offset, driven by
+// the frequency error and the adjustment, and a frequency error following a
+// random walk. The adjustment compensates the estimated frequency error and
+// corrects the estimated offset by the next sample. Noisy offsets are
+// filtered by setting MeasurementNoise to the packet delay variation.
+//
+// A KalmanServo is not safe for concurrent use.
+type KalmanServo struct {
+	ServoConfig
+	// MeasurementNoise is the variance of the offsets, in ns²
+	MeasurementNoise float64
+	// PhaseNoise is the white frequency noise of the clock, in ns²/s
+	PhaseNoise float64
+	// FrequencyNoise is the random walk frequency noise of the clock, in
+	// ppb²/s
+	FrequencyNoise float64
+
+	lock    servoLock
+	started bool
+	ts      time.Time
+	// x is the estimated offset in ns and frequency error in ppb of the
+	// clock, p their covariance
+	x    [2]float64
+	p    [2][2]float64
+	freq float64
+}
+
+// NewKalmanServo returns a KalmanServo of a clock adjusted by freq ppb.
+func NewKalmanServo(freq float64) *KalmanServo {
+	return &KalmanServo{
+		ServoConfig:      DefaultServoConfig(),
+		MeasurementNoise: DefaultKalmanMeasurementNoise,
+		PhaseNoise:       DefaultKalmanPhaseNoise,
+		FrequencyNoise:   DefaultKalmanFrequencyNoise,
+		freq:             freq,
+	}
+}
+
+// Sample implements Servo.
+func (s *KalmanServo) Sample(offset time.Duration, ts time.Time) (float64, ServoState) {
+	if s.started && (s.unlock(offset) || !ts.After(s.ts)) {
+		s.Reset()
+		return s.freq, s.lock.update(&s.ServoConfig, ServoUnlocked, offset)
+	}
+
+	z := float64(offset)
+	if !s.started {
+		// The adjustment is assumed to compensate the frequency error
+		s.x = [2]float64{z, -s.freq}
+		s.p = [2][2]float64{{s.MeasurementNoise, 0}, {0, kalmanFrequencyVariance}}
+		s.ts, s.started = ts, true
+		return s.freq, s.lock.update(&s.ServoConfig, ServoUnlocked, offset)
+	}
+
+	dt := ts.Sub(s.ts).Seconds()
+	s.ts = ts
+	s.predict(dt)
+	s.correct(z)
+
+	if s.lock.step(&s.ServoConfig, offset) {
+		s.freq, _ = s.clamp(-s.x[1])
+		// The clock is stepped by -offset
+		s.x[0] -= z
+		return s.freq, s.lock.update(&s.ServoConfig, ServoJump, offset)
+	}
+
+	s.freq, _ = s.clamp(-s.x[1] - s.x[0]/dt)
+
+	return s.freq, s.lock.update(&s.ServoConfig, ServoLocked, offset)
+}
+
+// predict propagates the estimate dt seconds with the current adjustment.
+func (s *KalmanServo) predict(dt float64) {
+	p := &s.p
+	q1, q2 := s.PhaseNoise, s.FrequencyNoise
+
+	s.x[0] += (s.x[1] + s.freq) * dt
+
+	p[0][0] += dt*(p[0][1]+p[1][0]) + dt*dt*p[1][1] + q1*dt + q2*dt*dt*dt/3
+	p[0][1] += dt*p[1][1] + q2*dt*dt/2
+	p[1][0] = p[0][1]
+	p[1][1] += q2 * dt
+}
+
+// correct updates the estimate with the offset z.
+func (s *KalmanServo) correct(z float64) {
+	p := &s.p
+
+	k0 := p[0][0] / (p[0][0] + s.MeasurementNoise)
+	k1 := p[1][0] / (p[0][0] + s.MeasurementNoise)
+
+	innovation := z - s.x[0]
+	s.x[0] += k0 * innovation
+	s.x[1] += k1 * innovation
+
+	p[1][1] -= k1 * p[0][1]
+	p[0][1] *= 1 - k0
+	p[1][0] = p[0][1]
+	p[0][0] *= 1 - k0
+}
+
+// Reset implements Servo.
+func (s *KalmanServo) Reset() {
+	s.started = false
+}
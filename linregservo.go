@@ -0,0 +1,117 @@
+package ptp
+
+import "time"
+
+// DefaultLinRegWindow is the default number of samples of LinRegServo.
+const DefaultLinRegWindow = 16
+
+// linRegPoint is a sample of LinRegServo, the offset y in ns the clock would
+// have without adjustments at x seconds.
+type linRegPoint struct {
+	x, y float64
+}
+
+// LinRegServo is a linear regression servo, similar to the linreg servo of
+// linuxptp. The adjustments are removed from the offsets, and the frequency
+// and offset of the unadjusted clock are fitted over a sliding window of
+// samples. The adjustment compensates the frequency and corrects the fitted
+// offset by the next sample, which averages out the packet delay variation
+// a PI servo follows.
+//
+// A LinRegServo is not safe for concurrent use.
+type LinRegServo struct {
+	ServoConfig
+	// Window is the number of samples of the regression
+	Window int
+
+	lock   servoLock
+	points []linRegPoint
+	origin time.Time
+	ts     time.Time
+	// phase is the time in ns added to the clock by the adjustments since
+	// origin
+	phase float64
+	freq  float64
+}
+
+// NewLinRegServo returns a LinRegServo of a clock adjusted by freq ppb.
+func NewLinRegServo(freq float64) *LinRegServo {
+	return &LinRegServo{
+		ServoConfig: DefaultServoConfig(),
+		Window:      DefaultLinRegWindow,
+		freq:        freq,
+	}
+}
+
+// Sample implements Servo.
+func (s *LinRegServo) Sample(offset time.Duration, ts time.Time) (float64, ServoState) {
+	if len(s.points) > 0 && (s.unlock(offset) || !ts.After(s.ts)) {
+		s.Reset()
+		return s.freq, s.lock.update(&s.ServoConfig, ServoUnlocked, offset)
+	}
+
+	var interval float64
+	if len(s.points) == 0 {
+		s.origin, s.phase = ts, 0
+	} else {
+		interval = ts.Sub(s.ts).Seconds()
+		s.phase += s.freq * interval
+	}
+	s.ts = ts
+
+	x := ts.Sub(s.origin).Seconds()
+	s.points = append(s.points, linRegPoint{x, float64(offset) - s.phase})
+	if n := len(s.points) - s.window(); n > 0 {
+		s.points = s.points[n:]
+	}
+
+	if len(s.points) < 2 {
+		return s.freq, s.lock.update(&s.ServoConfig, ServoUnlocked, offset)
+	}
+
+	drift, y := s.regress(x)
+
+	if s.lock.step(&s.ServoConfig, offset) {
+		s.freq, _ = s.clamp(-drift)
+		// The clock is stepped by -offset
+		s.phase -= float64(offset)
+		return s.freq, s.lock.update(&s.ServoConfig, ServoJump, offset)
+	}
+
+	s.freq, _ = s.clamp(-drift - (y+s.phase)/interval)
+
+	return s.freq, s.lock.update(&s.ServoConfig, ServoLocked, offset)
+}
+
+// regress returns the frequency in ppb of the unadjusted clock and its
+// offset in ns at x, fitted by least squares.
+func (s *LinRegServo) regress(x float64) (float64, float64) {
+	var mx, my float64
+	for _, p := range s.points {
+		mx += p.x
+		my += p.y
+	}
+	n := float64(len(s.points))
+	mx, my = mx/n, my/n
+
+	var sxx, sxy float64
+	for _, p := range s.points {
+		sxx += (p.x - mx) * (p.x - mx)
+		sxy += (p.x - mx) * (p.y - my)
+	}
+
+	slope := sxy / sxx
+	return slope, my + slope*(x-mx)
+}
+
+func (s *LinRegServo) window() int {
+	if s.Window < 2 {
+		return 2
+	}
+	return s.Window
+}
+
+// Reset implements Servo.
+func (s *LinRegServo) Reset() {
+	s.points = s.points[:0]
+}
@@ -0,0 +1,139 @@
+package ptp
+
+import "time"
+
+// MasterPort runs the master side of a port: periodic Announce messages built
+// from the data sets of the clock, one-step or two-step Sync messages and
+// Delay_Resp messages answering Delay_Req. The intervals and the two-step
+// mode are those of the Builder.
+//
+// A MasterPort is not safe for concurrent use.
+type MasterPort struct {
+	// Clock stamps originTimestamp of Sync messages and runs the timers.
+	Clock Clock
+
+	DefaultDS      DefaultDataSetTlv
+	TimeProperties TimePropertiesDataSetTlv
+	// Parent is parentDS of a clock synchronized to another master, nil if
+	// the clock is the grandmaster.
+	Parent *ParentDataSetTlv
+	// StepsRemoved is currentDS.stepsRemoved, used with Parent.
+	StepsRemoved uint16
+
+	builder      *Builder
+	nextAnnounce time.Time
+	nextSync     time.Time
+}
+
+// NewMasterPort returns a MasterPort of the clock of defaultDS sending messages built
+// by b, with the time of clock.
+func NewMasterPort(b *Builder, defaultDS DefaultDataSetTlv, clock Clock) *MasterPort {
+	return &MasterPort{
+		Clock:     clock,
+		DefaultDS: defaultDS,
+		builder:   b,
+	}
+}
+
+// Poll returns the Announce and Sync messages due at the current time. The
+// first call sends both.
+func (m *MasterPort) Poll() ([]Message, error) {
+	now := timerNow(m.Clock)
+
+	var msgs []Message
+	if due(&m.nextAnnounce, now, m.builder.LogAnnounceInterval) {
+		msgs = append(msgs, m.Announce())
+	}
+
+	if due(&m.nextSync, now, m.builder.LogSyncInterval) {
+		origin, err := m.Clock.Now()
+		if err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, m.builder.Sync(origin))
+	}
+
+	return msgs, nil
+}
+
+// due reports whether the message scheduled at next is due at now, and
+// schedules the following one an interval later. Messages of a special
+// interval are never due and next is cleared.
+func due(next *time.Time, now time.Time, interval LogInterval) bool {
+	d, ok := interval.Interval()
+	if !ok {
+		*next = time.Time{}
+		return false
+	}
+
+	if !next.IsZero() && now.Before(*next) {
+		return false
+	}
+
+	*next = next.Add(d)
+	if !now.Before(*next) {
+		// Late, do not send the missed messages
+		*next = now.Add(d)
+	}
+
+	return true
+}
+
+// Deadline returns the time the next periodic message is due, zero if none
+// is scheduled.
+func (m *MasterPort) Deadline() time.Time {
+	switch {
+	case m.nextSync.IsZero():
+		return m.nextAnnounce
+	case m.nextAnnounce.IsZero(), m.nextSync.Before(m.nextAnnounce):
+		return m.nextSync
+	}
+	return m.nextAnnounce
+}
+
+// Announce returns the next Announce message. The grandmaster is the clock
+// itself unless Parent is set.
+func (m *MasterPort) Announce() *AnnounceMsg {
+	tp := m.TimeProperties
+
+	body := AnnounceMsg{
+		Header: Header{Flags: Flags{
+			LI61:               tp.LI61,
+			LI59:               tp.LI59,
+			UtcReasonable:      tp.UTCV,
+			TimeScale:          tp.PTP,
+			TimeTraceable:      tp.TTRA,
+			FrequencyTraceable: tp.FTRA,
+		}},
+		CurrentUtcOffset: int16(tp.CurrentUtcOffset),
+		GMPriority1:      m.DefaultDS.Priority1,
+		GMClockQuality:   m.DefaultDS.ClockQuality,
+		GMPriority2:      m.DefaultDS.Priority2,
+		TimeSource:       tp.TimeSource,
+	}
+
+	if p := m.Parent; p != nil {
+		body.GMPriority1 = p.GrandmasterPriority1
+		body.GMClockQuality = p.GrandmasterClockQuality
+		body.GMPriority2 = p.GrandmasterPriority2
+		body.GMIdentity = p.GrandmasterIdentity
+		body.StepsRemoved = m.StepsRemoved
+	}
+
+	return m.builder.Announce(body)
+}
+
+// SyncSent records the egress timestamp of sync and returns its Follow_Up,
+// nil for a one-step Sync.
+func (m *MasterPort) SyncSent(sync *SyncMsg, egress time.Time) *FollowUpMsg {
+	if !sync.TwoSteps {
+		return nil
+	}
+
+	return m.builder.FollowUp(sync, egress)
+}
+
+// HandleDelayReq returns the Delay_Resp answering req received at ingress.
+func (m *MasterPort) HandleDelayReq(req *DelReqMsg, ingress time.Time) *DelRespMsg {
+	return m.builder.DelayResp(req, ingress)
+}
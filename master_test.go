@@ -0,0 +1,214 @@
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMasterPoll(t *testing.T) {
+	id := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+
+	b := NewBuilder(id, 0)
+	b.LogAnnounceInterval = 1
+	b.LogSyncInterval = -1
+	clock := NewSimClock(time.Unix(1000, 0), 0, 0, 1)
+	m := NewMasterPort(b, DefaultDataSetTlv{ClockIdentity: id.ClockIdentity}, clock)
+
+	var tests = []struct {
+		desc string
+		wait time.Duration
+		want []MsgType
+	}{
+		{desc: "First", want: []MsgType{AnnounceMsgType, SyncMsgType}},
+		{desc: "Within the intervals", wait: 499 * time.Millisecond},
+		{desc: "Sync interval", wait: time.Millisecond, want: []MsgType{SyncMsgType}},
+		{desc: "Sync and Announce intervals", wait: 1500 * time.Millisecond, want: []MsgType{AnnounceMsgType, SyncMsgType}},
+		{desc: "Missed Sync messages", wait: 1700 * time.Millisecond, want: []MsgType{SyncMsgType}},
+		{desc: "Announce", wait: 300 * time.Millisecond, want: []MsgType{AnnounceMsgType}},
+		{desc: "Within the rescheduled Sync interval", wait: 199 * time.Millisecond},
+		{desc: "Rescheduled from the late Sync", wait: time.Millisecond, want: []MsgType{SyncMsgType}},
+	}
+
+	for _, tt := range tests {
+		clock.Advance(tt.wait)
+
+		msgs, err := m.Poll()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.desc, err)
+		}
+
+		var got []MsgType
+		for _, msg := range msgs {
+			switch msg := msg.(type) {
+			case *AnnounceMsg:
+				got = append(got, msg.MessageType)
+			case *SyncMsg:
+				got = append(got, msg.MessageType)
+			}
+		}
+
+		if want := tt.want; len(want) != len(got) || (len(want) > 0 && !equalMsgTypes(want, got)) {
+			t.Fatalf("%s: unexpected messages: %v != %v", tt.desc, want, got)
+		}
+	}
+}
+
+func TestMasterPollSpecialInterval(t *testing.T) {
+	id := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+	now := time.Unix(1000, 0)
+
+	b := NewBuilder(id, 0)
+	b.LogSyncInterval = LogIntervalStop
+	clock := NewSimClock(now, 0, 0, 1)
+	m := NewMasterPort(b, DefaultDataSetTlv{ClockIdentity: id.ClockIdentity}, clock)
+
+	// Sync messages are never sent, Announce messages are
+	for i := 0; i < 3; i++ {
+		msgs, err := m.Poll()
+		if err != nil || len(msgs) != 1 {
+			t.Fatalf("unexpected messages: %v", msgs)
+		}
+		if _, ok := msgs[0].(*AnnounceMsg); !ok {
+			t.Fatalf("unexpected message: %v", msgs[0])
+		}
+
+		if want, got := now.Add(time.Second), m.Deadline(); !want.Equal(got) {
+			t.Fatalf("unexpected deadline: %v != %v", want, got)
+		}
+		now = now.Add(time.Second)
+		clock.Advance(time.Second)
+	}
+
+	b.LogAnnounceInterval = LogIntervalInitial
+	if msgs, err := m.Poll(); err != nil || len(msgs) != 0 {
+		t.Fatalf("unexpected messages: %v", msgs)
+	}
+	if d := m.Deadline(); !d.IsZero() {
+		t.Fatalf("unexpected deadline: %v", d)
+	}
+}
+
+func equalMsgTypes(a, b []MsgType) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMasterAnnounce(t *testing.T) {
+	id := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+	defaultDS := DefaultDataSetTlv{
+		Priority1:     127,
+		ClockQuality:  ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 0x4e5d},
+		Priority2:     128,
+		ClockIdentity: id.ClockIdentity,
+	}
+	timeProperties := TimePropertiesDataSetTlv{
+		CurrentUtcOffset: 37,
+		UTCV:             true,
+		PTP:              true,
+		TTRA:             true,
+		FTRA:             true,
+		TimeSource:       TimeSourceGPS,
+	}
+
+	m := NewMasterPort(NewBuilder(id, 0), defaultDS, nil)
+	m.TimeProperties = timeProperties
+
+	a := m.Announce()
+	if err := a.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := AnnounceMsg{
+		Header:           a.Header,
+		CurrentUtcOffset: 37,
+		GMPriority1:      127,
+		GMClockQuality:   defaultDS.ClockQuality,
+		GMPriority2:      128,
+		GMIdentity:       id.ClockIdentity,
+		TimeSource:       TimeSourceGPS,
+	}
+	if want.String() != a.String() {
+		t.Fatalf("unexpected Announce:\n- want: %v\n-  got: %v", want, a)
+	}
+
+	wantFlags := Flags{UtcReasonable: true, TimeScale: true, TimeTraceable: true, FrequencyTraceable: true}
+	if a.Flags != wantFlags {
+		t.Fatalf("unexpected flags: %v != %v", wantFlags, a.Flags)
+	}
+
+	// Boundary clock announcing its grandmaster
+	m.Parent = &ParentDataSetTlv{
+		GrandmasterPriority1:    100,
+		GrandmasterClockQuality: ClockQuality{ClockClass: 6, ClockAccuracy: ClockAccuracy25ns},
+		GrandmasterPriority2:    101,
+		GrandmasterIdentity:     0x000af7fffe000009,
+	}
+	m.StepsRemoved = 2
+
+	a = m.Announce()
+	if err := a.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if a.GMIdentity != 0x000af7fffe000009 || a.GMPriority1 != 100 || a.GMPriority2 != 101 || a.StepsRemoved != 2 ||
+		a.GMClockQuality != m.Parent.GrandmasterClockQuality {
+		t.Fatalf("unexpected grandmaster: %v", a)
+	}
+}
+
+func TestMasterSync(t *testing.T) {
+	id := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+	slave := NewBuilder(PortIdentity{ClockIdentity: 0x000af7fffe000002, PortNumber: 1}, 0)
+	now := time.Unix(1000, 0)
+	egress := now.Add(123)
+	// The clock is in the PTP timescale, the timers are not
+	clock := NewSimClock(now, 37*time.Second, 0, 1)
+
+	for _, twoStep := range []bool{false, true} {
+		b := NewBuilder(id, 0)
+		b.TwoStep = twoStep
+		m := NewMasterPort(b, DefaultDataSetTlv{ClockIdentity: id.ClockIdentity}, clock)
+
+		msgs, err := m.Poll()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sync := msgs[1].(*SyncMsg)
+		if want, got := twoStep, sync.TwoSteps; want != got {
+			t.Fatalf("unexpected twoStepFlag: %v != %v", want, got)
+		}
+
+		if want, got := now.Add(37*time.Second), sync.OriginTimestamp; !want.Equal(got) {
+			t.Fatalf("unexpected originTimestamp: %v != %v", want, got)
+		}
+
+		followUp := m.SyncSent(sync, egress)
+		if !twoStep {
+			if followUp != nil {
+				t.Fatal("unexpected Follow_Up of one-step Sync")
+			}
+			continue
+		}
+
+		if followUp.SequenceID != sync.SequenceID || !followUp.PreciseOriginTimestamp.Equal(egress) {
+			t.Fatalf("unexpected Follow_Up: %v", followUp)
+		}
+	}
+
+	m := NewMasterPort(NewBuilder(id, 0), DefaultDataSetTlv{ClockIdentity: id.ClockIdentity}, clock)
+	req := slave.DelayReq(now)
+	resp := m.HandleDelayReq(req, egress)
+	if err := resp.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.SequenceID != req.SequenceID || !resp.ReceiveTimestamp.Equal(egress) ||
+		resp.RequestingPortIdentity != req.ClockIdentity || resp.RequestingPortID != req.PortNumber {
+		t.Fatalf("unexpected Delay_Resp: %v", resp)
+	}
+}
@@ -1,5 +1,11 @@
 package ptp
 
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
 // ActionFieldType...
 type ActionFiledType uint8
 
@@ -19,16 +25,164 @@ type MgmtMsg struct {
 	PortNumber           uint16
 	StartingBoundaryHops uint8
 	BoundaryHops         uint8
-	actionField          ActionFiledType
+	ActionField          ActionFiledType
 	ManagementTlv
+	// SMPTE ST 2059-2 synchronization metadata TLV, nil if not present
+	SmpteSync *SmpteSyncTlv
 }
 
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t MgmtMsg) MarshalBinary() ([]byte, error) {
-	return []byte{}, nil
+
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a MgmtMsg in binary form.
+func (t MgmtMsg) MarshalLen() int {
+	return HeaderLen + MgmtPayloadLen + t.tlvsLen()
+}
+
+// AppendBinary appends the binary form of a MgmtMsg to b.
+func (t MgmtMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a MgmtMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t MgmtMsg) MarshalTo(b []byte) (int, error) {
+	if t.Header.MessageType != MgmtMsgType {
+		return 0, ErrInvalidMsgType
+	}
+
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
+
+	if t.Header.MessageLength == 0 {
+		t.Header.MessageLength = uint16(n)
+	}
+
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
+	offset := HeaderLen
+
+	// Target port identity
+	binary.BigEndian.PutUint64(b[offset:offset+ClockIdentityLen], t.ClockIdentity)
+	offset += ClockIdentityLen
+
+	binary.BigEndian.PutUint16(b[offset:offset+SourcePortNumberLen], t.PortNumber)
+	offset += SourcePortNumberLen
+
+	b[offset] = t.StartingBoundaryHops
+	offset++
+
+	b[offset] = t.BoundaryHops
+	offset++
+
+	// Reserved nibble and actionField
+	b[offset] = uint8(t.ActionField) & 0x0f
+	offset++
+
+	// Reserved byte
+	b[offset] = 0
+	offset++
+
+	if t.SmpteSync != nil {
+		if _, err := t.SmpteSync.MarshalTo(b[offset:n]); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
 }
 
-// UnmarshalBinary unmarshals a byte slice into a Frame.
+// tlvsLen returns the length of TLVs following the Management body.
+func (t MgmtMsg) tlvsLen() int {
+	if t.SmpteSync != nil {
+		return t.SmpteSync.MarshalLen()
+	}
+
+	return 0
+}
+
+// UnmarshalBinary unmarshals a byte slice into a MgmtMsg.
+//
+// If the byte slice does not contain enough data to unmarshal a valid MgmtMsg,
+// io.ErrUnexpectedEOF is returned.
+// Unknown TLVs are skipped, trailing bytes too short to hold a TLV are
+// ignored.
 func (t *MgmtMsg) UnmarshalBinary(b []byte) error {
-	return nil
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
+
+// unmarshal decodes a MgmtMsg accordingly with the options.
+func (t *MgmtMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, MgmtMsgType, HeaderLen+MgmtPayloadLen)
+	if err != nil {
+		return err
+	}
+
+	if err = t.Header.unmarshal(b, o); err != nil {
+		return err
+	}
+
+	if t.Header.MessageType != MgmtMsgType {
+		return decodeError(MgmtMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
+	}
+
+	offset := HeaderLen
+
+	t.ClockIdentity = binary.BigEndian.Uint64(b[offset : offset+ClockIdentityLen])
+	offset += ClockIdentityLen
+
+	t.PortNumber = binary.BigEndian.Uint16(b[offset : offset+SourcePortNumberLen])
+	offset += SourcePortNumberLen
+
+	t.StartingBoundaryHops = b[offset]
+	offset++
+
+	t.BoundaryHops = b[offset]
+	offset++
+
+	t.ActionField = ActionFiledType(b[offset] & 0x0f)
+
+	// Reserved nibble and reserved byte
+	if err = o.reserved(&t.Header, []byte{b[offset] & 0xf0, b[offset+1]}, offset); err != nil {
+		return err
+	}
+	offset += 2
+
+	return t.unmarshalTlvs(b[offset:], offset, o)
+}
+
+// unmarshalTlvs decodes the TLVs following the Management body. Unknown TLVs
+// are skipped. b starts at offset of the message.
+func (t *MgmtMsg) unmarshalTlvs(b []byte, offset int, o *DecodeOptions) error {
+	t.SmpteSync = nil
+
+	return o.walkTlvs(b, MgmtMsgType, offset, func(tlvType TlvType, tlv []byte) error {
+		if tlvType != OrganizationExtension || len(tlv) < 10 {
+			return nil
+		}
+
+		if bytes.Equal(tlv[4:7], smpteOrganizationID) && bytes.Equal(tlv[7:10], []byte{0x0, 0x0, 0x1}) {
+			t.SmpteSync = new(SmpteSyncTlv)
+			return t.SmpteSync.UnmarshalBinary(tlv)
+		}
+
+		return nil
+	})
+}
+
+// Validate checks that a MgmtMsg conforms to IEEE 1588.
+func (t MgmtMsg) Validate() error {
+	return t.Header.validate(MgmtMsgType, t.MarshalLen(), true)
 }
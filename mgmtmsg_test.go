@@ -0,0 +1,135 @@
+package ptp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+var mgmtMsgBytes = append([]byte{0xd, 0x2, 0x0, 0x64, 0x0, 0x0, 0x0, 0x0,
+	0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+	0x0, 0x0, 0x0, 0x0,
+	0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x1, 0x0, 0x7, 0x4, 0x7f,
+	// targetPortIdentity
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	// startingBoundaryHops, boundaryHops
+	0x1, 0x1,
+	// actionField, reserved
+	0x3, 0x0,
+}, smpteSyncTlvBytes...)
+
+func TestMarshalMgmt(t *testing.T) {
+	var tests = []struct {
+		desc string
+		m    *MgmtMsg
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "SMPTE synchronization metadata",
+			m: &MgmtMsg{
+				Header: Header{
+					MessageType:      MgmtMsgType,
+					ClockIdentity:    0x000af7fffe42a753,
+					PortNumber:       1,
+					SequenceID:       7,
+					LogMessagePeriod: 0x7f,
+				},
+				ClockIdentity:        0xffffffffffffffff,
+				PortNumber:           0xffff,
+				StartingBoundaryHops: 1,
+				BoundaryHops:         1,
+				ActionField:          Command,
+				SmpteSync:            &smpteSyncTlv,
+			},
+			b: mgmtMsgBytes,
+		},
+		{
+			desc: "Invalid message type",
+			m: &MgmtMsg{
+				Header: Header{
+					MessageType: AnnounceMsgType,
+				},
+			},
+			err: ErrInvalidMsgType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.m.MarshalBinary()
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalMgmt(t *testing.T) {
+	var tests = []struct {
+		desc string
+		m    *MgmtMsg
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "SMPTE synchronization metadata",
+			m: &MgmtMsg{
+				Header: Header{
+					MessageType:      MgmtMsgType,
+					MessageLength:    HeaderLen + MgmtPayloadLen + SmpteSyncTlvLen + 4,
+					VersionPTP:       Version2,
+					ClockIdentity:    0x000af7fffe42a753,
+					PortNumber:       1,
+					SequenceID:       7,
+					LogMessagePeriod: 0x7f,
+				},
+				ClockIdentity:        0xffffffffffffffff,
+				PortNumber:           0xffff,
+				StartingBoundaryHops: 1,
+				BoundaryHops:         1,
+				ActionField:          Command,
+				SmpteSync:            &smpteSyncTlv,
+			},
+			b: mgmtMsgBytes,
+		},
+		{
+			desc: "Truncated TLV",
+			b:    mgmtMsgBytes[:len(mgmtMsgBytes)-4],
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "Invalid length",
+			b:    mgmtMsgBytes[:HeaderLen+MgmtPayloadLen-1],
+			err:  io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := new(MgmtMsg)
+			err := m.UnmarshalBinary(tt.b)
+			if err != nil {
+				if want, got := tt.err, err; !errors.Is(got, want) {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.m, m; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
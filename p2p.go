@@ -0,0 +1,299 @@
+package ptp
+
+import (
+	"sort"
+	"time"
+)
+
+// Defaults of the peer delay mechanism, IEEE 802.1AS-2020 11.5.
+const (
+	// DefaultNeighborPropDelayThresh is the largest meanLinkDelay of a link
+	// of an asCapable port
+	DefaultNeighborPropDelayThresh = 800 * time.Nanosecond
+	// DefaultAllowedLostResponses is the number of consecutive Pdelay_Req
+	// left without response before a port is no longer asCapable
+	DefaultAllowedLostResponses = 3
+	// DefaultPDelayFilterLength is the number of measurements meanLinkDelay
+	// and neighborRateRatio are computed from
+	DefaultPDelayFilterLength = 8
+)
+
+// maxRateRatioDeviation bounds neighborRateRatio, larger deviations from 1
+// come from wrong timestamps and are discarded
+const maxRateRatioDeviation = 1e-3
+
+// pdelayTimestamps holds the timestamps of a response, t3 of the responder
+// and t4 of the initiator.
+type pdelayTimestamps struct {
+	t3, t4 time.Time
+}
+
+// PDelayInitiator runs the initiator side of the peer delay mechanism,
+// IEEE 1588-2008 11.4 and IEEE 802.1AS-2020 11.2.19: it sends Pdelay_Req
+// messages periodically and computes meanLinkDelay and neighborRateRatio
+// from the responses of one-step and two-step responders.
+//
+// meanLinkDelay is the median of the last FilterLength measurements, which
+// rejects outliers. neighborRateRatio is computed from the responder
+// timestamps of the oldest and newest of the last FilterLength responses.
+//
+// A PDelayInitiator is not safe for concurrent use.
+type PDelayInitiator struct {
+	// Clock runs the request interval, time.Now if nil.
+	Clock Clock
+
+	NeighborPropDelayThresh time.Duration
+	AllowedLostResponses    int
+	FilterLength            int
+
+	builder  *Builder
+	lastSent time.Time
+
+	// req is the last Pdelay_Req, t1 its transmission time
+	req      uint16
+	t1       time.Time
+	hasReq   bool
+	reqSent  bool
+	answered bool
+
+	// resp is the Pdelay_Resp waiting for its Pdelay_Resp_Follow_Up
+	resp      *PDelRespMsg
+	t4        time.Time
+	hasResp   bool
+	responder PortIdentity
+
+	lostResponses      int
+	multipleResponders bool
+
+	delays         []time.Duration
+	meanLinkDelay  time.Duration
+	timestamps     []pdelayTimestamps
+	rateRatio      RateRatio
+	rateRatioValid bool
+}
+
+// NewPDelayInitiator returns a PDelayInitiator sending Pdelay_Req messages
+// built by b every b.LogMinPdelayReqInterval of clock.
+func NewPDelayInitiator(b *Builder, clock Clock) *PDelayInitiator {
+	return &PDelayInitiator{
+		Clock:                   clock,
+		NeighborPropDelayThresh: DefaultNeighborPropDelayThresh,
+		AllowedLostResponses:    DefaultAllowedLostResponses,
+		FilterLength:            DefaultPDelayFilterLength,
+		builder:                 b,
+		rateRatio:               1,
+	}
+}
+
+// Poll returns the next Pdelay_Req message once the request interval
+// elapsed since the last one, nil otherwise. No request is sent for a
+// special interval.
+func (p *PDelayInitiator) Poll() *PDelReqMsg {
+	interval, ok := p.builder.LogMinPdelayReqInterval.Interval()
+	if !ok {
+		return nil
+	}
+
+	now := timerNow(p.Clock)
+	if !p.lastSent.IsZero() && now.Sub(p.lastSent) < interval {
+		return nil
+	}
+	p.lastSent = now
+
+	return p.Request()
+}
+
+// Request returns the next Pdelay_Req message. Its transmission time is
+// recorded with RequestSent. The previous request counts as lost if it was
+// not answered.
+func (p *PDelayInitiator) Request() *PDelReqMsg {
+	if p.hasReq && !p.answered {
+		p.lostResponses++
+	}
+
+	m := p.builder.PDelayReq()
+	p.req, p.hasReq, p.reqSent, p.answered = m.SequenceID, true, false, false
+	p.hasResp = false
+	p.multipleResponders = false
+
+	return m
+}
+
+// RequestSent records the transmission time t1 of m.
+func (p *PDelayInitiator) RequestSent(m *PDelReqMsg, t1 time.Time) {
+	if p.hasReq && m.SequenceID == p.req {
+		p.t1, p.reqSent = t1, true
+	}
+}
+
+// matches reports whether a response with sequenceId seq is addressed to the
+// last request of the port.
+func (p *PDelayInitiator) matches(seq uint16, requester PortIdentity) bool {
+	return p.hasReq && p.reqSent && seq == p.req && requester == p.builder.PortIdentity
+}
+
+// HandleResp records m received at t4. The response of a one-step responder
+// completes the measurement and true is returned, a two-step response waits
+// for its Pdelay_Resp_Follow_Up.
+func (p *PDelayInitiator) HandleResp(m *PDelRespMsg, t4 time.Time) bool {
+	if !p.matches(m.SequenceID, PortIdentity{m.ClockIdentity, m.PortNumber}) {
+		return false
+	}
+
+	// Responses of several ports to one request, 802.1AS-2020 11.2.2
+	source := m.SourcePortIdentity()
+	if p.answered || p.hasResp {
+		if source != p.responder {
+			p.multipleResponders = true
+		}
+		return false
+	}
+	p.responder = source
+
+	if m.TwoSteps {
+		p.resp, p.t4, p.hasResp = m, t4, true
+		return false
+	}
+
+	// The turnaround time t3 - t2 is in correctionField
+	p.complete(float64(t4.Sub(p.t1)), m.Correction())
+
+	return true
+}
+
+// HandleRespFollowUp completes the measurement of the two-step response
+// followed by m and returns true.
+func (p *PDelayInitiator) HandleRespFollowUp(m *PDelRespFollowUpMsg) bool {
+	if !p.hasResp || m.SourcePortIdentity() != p.responder ||
+		!p.matches(m.SequenceID, PortIdentity{m.ClockIdentity, m.PortNumber}) {
+		return false
+	}
+	p.hasResp = false
+
+	t2, t3 := p.resp.ReceiveTimestamp, m.OriginTimestamp
+	p.updateRateRatio(pdelayTimestamps{t3, p.t4})
+
+	turnaround := t3.Sub(t2) + p.resp.Correction() + m.Correction()
+	p.complete(float64(p.t4.Sub(p.t1))*float64(p.rateRatio), turnaround)
+
+	return true
+}
+
+// complete records the measurement of a round trip of rtt nanoseconds, in
+// the time base of the responder, with turnaround time in the responder.
+func (p *PDelayInitiator) complete(rtt float64, turnaround time.Duration) {
+	p.answered = true
+	p.lostResponses = 0
+
+	d := time.Duration(rtt-float64(turnaround)) / 2
+	if d < 0 {
+		return
+	}
+
+	p.delays = append(p.delays, d)
+	if n := len(p.delays) - p.filterLength(); n > 0 {
+		p.delays = p.delays[n:]
+	}
+
+	sorted := append([]time.Duration(nil), p.delays...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	p.meanLinkDelay = sorted[len(sorted)/2]
+}
+
+// updateRateRatio records ts and computes neighborRateRatio from the oldest
+// recorded timestamps.
+func (p *PDelayInitiator) updateRateRatio(ts pdelayTimestamps) {
+	p.timestamps = append(p.timestamps, ts)
+	if n := len(p.timestamps) - p.filterLength(); n > 0 {
+		p.timestamps = p.timestamps[n:]
+	}
+
+	if len(p.timestamps) < 2 {
+		return
+	}
+
+	first := p.timestamps[0]
+	d4 := ts.t4.Sub(first.t4)
+	if d4 <= 0 {
+		return
+	}
+
+	r := float64(ts.t3.Sub(first.t3)) / float64(d4)
+	if r < 1-maxRateRatioDeviation || r > 1+maxRateRatioDeviation {
+		return
+	}
+
+	p.rateRatio, p.rateRatioValid = RateRatio(r), true
+}
+
+func (p *PDelayInitiator) filterLength() int {
+	if p.FilterLength < 1 {
+		return 1
+	}
+	return p.FilterLength
+}
+
+// MeanLinkDelay returns meanLinkDelay in the time base of the responder, 0
+// until the first measurement.
+func (p *PDelayInitiator) MeanLinkDelay() time.Duration {
+	return p.meanLinkDelay
+}
+
+// NeighborRateRatio returns the ratio of the frequency of the responder to
+// the frequency of the local clock, and whether it was measured. It is 1
+// until two two-step responses were received.
+func (p *PDelayInitiator) NeighborRateRatio() (RateRatio, bool) {
+	return p.rateRatio, p.rateRatioValid
+}
+
+// AsCapable reports whether the link may carry time, 802.1AS-2020 11.2.2:
+// meanLinkDelay was measured and does not exceed NeighborPropDelayThresh,
+// no more than AllowedLostResponses requests are left without response,
+// and a single port responds.
+func (p *PDelayInitiator) AsCapable() bool {
+	return len(p.delays) > 0 &&
+		p.meanLinkDelay <= p.NeighborPropDelayThresh &&
+		p.lostResponses <= p.AllowedLostResponses &&
+		!p.multipleResponders
+}
+
+// PDelayResponder answers the Pdelay_Req messages received by a port.
+type PDelayResponder struct {
+	builder *Builder
+}
+
+// NewPDelayResponder returns a PDelayResponder answering with messages built
+// by b.
+func NewPDelayResponder(b *Builder) *PDelayResponder {
+	return &PDelayResponder{builder: b}
+}
+
+// Respond returns the two-step Pdelay_Resp answering req received at t2. It
+// is followed by the message returned by FollowUp.
+func (r *PDelayResponder) Respond(req *PDelReqMsg, t2 time.Time) *PDelRespMsg {
+	m := r.builder.PDelayResp(req, t2)
+	m.TwoSteps = true
+
+	return m
+}
+
+// FollowUp returns the Pdelay_Resp_Follow_Up of the response to req sent at
+// t3. It carries the correctionField of req.
+func (r *PDelayResponder) FollowUp(req *PDelReqMsg, t3 time.Time) *PDelRespFollowUpMsg {
+	m := r.builder.PDelayRespFollowUp(req, t3)
+	m.CorrectionNs = req.CorrectionNs
+	m.CorrectionSubNs = req.CorrectionSubNs
+
+	return m
+}
+
+// RespondOneStep returns the one-step Pdelay_Resp answering req received at
+// t2 and sent at t3. The turnaround time t3 - t2 is added to the
+// correctionField of req, the requestReceiptTimestamp is 0.
+func (r *PDelayResponder) RespondOneStep(req *PDelReqMsg, t2, t3 time.Time) *PDelRespMsg {
+	m := r.builder.PDelayResp(req, time.Unix(0, 0))
+	m.TwoSteps = false
+	m.SetCorrection(req.Correction() + t3.Sub(t2))
+
+	return m
+}
@@ -0,0 +1,275 @@
+package ptp
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPDelay(t *testing.T) {
+	const (
+		delay      = 500 * time.Nanosecond
+		turnaround = 10 * time.Microsecond
+	)
+
+	initiatorID := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+	responderID := PortIdentity{ClockIdentity: 0x000af7fffe000002, PortNumber: 1}
+	// The responder clock is 1h ahead and rate is its rate relative to the
+	// initiator
+	epoch := time.Unix(1000, 0)
+	responderTime := func(local time.Time, rate float64) time.Time {
+		return epoch.Add(time.Hour + time.Duration(float64(local.Sub(epoch))*rate))
+	}
+
+	// exchange runs a Pdelay_Req exchange started at local time t1 and
+	// returns whether it completed
+	exchange := func(p *PDelayInitiator, r *PDelayResponder, t1 time.Time, delay time.Duration, rate float64, twoStep bool) bool {
+		req := p.Request()
+		p.RequestSent(req, t1)
+
+		t2 := responderTime(t1.Add(delay), rate)
+		t3 := t2.Add(turnaround)
+		t4 := t1.Add(delay + time.Duration(float64(turnaround)/rate) + delay)
+
+		if !twoStep {
+			return p.HandleResp(r.RespondOneStep(req, t2, t3), t4)
+		}
+
+		if p.HandleResp(r.Respond(req, t2), t4) {
+			t.Fatal("unexpected completion by two-step Pdelay_Resp")
+		}
+
+		return p.HandleRespFollowUp(r.FollowUp(req, t3))
+	}
+
+	var tests = []struct {
+		desc      string
+		rate      float64
+		twoStep   bool
+		delays    []time.Duration
+		want      time.Duration
+		rateValid bool
+		asCapable bool
+	}{
+		{
+			desc:      "Two-step",
+			rate:      1,
+			twoStep:   true,
+			delays:    []time.Duration{delay},
+			want:      delay,
+			asCapable: true,
+		},
+		{
+			desc:      "One-step",
+			rate:      1,
+			delays:    []time.Duration{delay},
+			want:      delay,
+			asCapable: true,
+		},
+		{
+			desc:      "Responder 100ppm faster",
+			rate:      1.0001,
+			twoStep:   true,
+			delays:    []time.Duration{delay, delay, delay, delay},
+			want:      delay,
+			rateValid: true,
+			asCapable: true,
+		},
+		{
+			desc:      "Responder 100ppm slower",
+			rate:      0.9999,
+			twoStep:   true,
+			delays:    []time.Duration{delay, delay, delay},
+			want:      delay,
+			rateValid: true,
+			asCapable: true,
+		},
+		{
+			desc:      "Outliers",
+			rate:      1,
+			twoStep:   true,
+			delays:    []time.Duration{delay, 100 * delay, delay, delay / 100, delay},
+			want:      delay,
+			rateValid: true,
+			asCapable: true,
+		},
+		{
+			desc:    "Long link",
+			rate:    1,
+			twoStep: true,
+			delays:  []time.Duration{2 * time.Microsecond},
+			want:    2 * time.Microsecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := NewPDelayInitiator(NewBuilder(initiatorID, 0), nil)
+			r := NewPDelayResponder(NewBuilder(responderID, 0))
+
+			for i, d := range tt.delays {
+				if !exchange(p, r, epoch.Add(time.Duration(i)*time.Second), d, tt.rate, tt.twoStep) {
+					t.Fatalf("exchange %d did not complete", i)
+				}
+			}
+
+			// Timestamps are truncated to nanoseconds
+			if want, got := tt.want, p.MeanLinkDelay(); math.Abs(float64(want-got)) > 1 {
+				t.Fatalf("unexpected meanLinkDelay: %v != %v", want, got)
+			}
+
+			ratio, valid := p.NeighborRateRatio()
+			if want, got := tt.rateValid, valid; want != got {
+				t.Fatalf("unexpected neighborRateRatio validity: %v != %v", want, got)
+			}
+
+			want := 1.0
+			if tt.rateValid {
+				want = tt.rate
+			}
+			if got := float64(ratio); math.Abs(want-got) > 1e-8 {
+				t.Fatalf("unexpected neighborRateRatio: %v != %v", want, got)
+			}
+
+			if want, got := tt.asCapable, p.AsCapable(); want != got {
+				t.Fatalf("unexpected asCapable: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestPDelayAsCapable(t *testing.T) {
+	initiatorID := PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 1}
+	now := time.Unix(1000, 0)
+	clock := NewSimClock(now, 0, 0, 1)
+	advance := func(d time.Duration) {
+		now = now.Add(d)
+		clock.Advance(d)
+	}
+
+	p := NewPDelayInitiator(NewBuilder(initiatorID, 0), clock)
+	r := NewPDelayResponder(NewBuilder(PortIdentity{ClockIdentity: 0x000af7fffe000002, PortNumber: 1}, 0))
+	other := NewPDelayResponder(NewBuilder(PortIdentity{ClockIdentity: 0x000af7fffe000003, PortNumber: 1}, 0))
+
+	if p.AsCapable() {
+		t.Fatal("unexpected asCapable before any measurement")
+	}
+
+	// respond answers the request sent now with the given responders
+	respond := func(responders ...*PDelayResponder) {
+		req := p.Poll()
+		if req == nil {
+			t.Fatal("expected Pdelay_Req")
+		}
+		p.RequestSent(req, now)
+
+		for _, r := range responders {
+			p.HandleResp(r.Respond(req, now), now.Add(time.Microsecond))
+			p.HandleRespFollowUp(r.FollowUp(req, now))
+		}
+
+		advance(time.Second)
+	}
+
+	respond(r)
+	if !p.AsCapable() {
+		t.Fatal("expected asCapable")
+	}
+
+	// One request per interval
+	advance(-500 * time.Millisecond)
+	if req := p.Poll(); req != nil {
+		t.Fatal("unexpected Pdelay_Req within the interval")
+	}
+	advance(500 * time.Millisecond)
+
+	// No requests for a special interval
+	p.builder.LogMinPdelayReqInterval = LogIntervalStop
+	if req := p.Poll(); req != nil {
+		t.Fatal("unexpected Pdelay_Req of a special interval")
+	}
+	p.builder.LogMinPdelayReqInterval = 0
+
+	for i := 0; i < DefaultAllowedLostResponses+1; i++ {
+		respond()
+	}
+	if !p.AsCapable() {
+		t.Fatal("expected asCapable with allowed lost responses")
+	}
+
+	respond()
+	if p.AsCapable() {
+		t.Fatal("unexpected asCapable after lost responses")
+	}
+
+	respond(r)
+	if !p.AsCapable() {
+		t.Fatal("expected asCapable after response")
+	}
+
+	respond(r, other)
+	if p.AsCapable() {
+		t.Fatal("unexpected asCapable with multiple responders")
+	}
+
+	respond(r)
+	if !p.AsCapable() {
+		t.Fatal("expected asCapable with a single responder")
+	}
+}
+
+func TestPDelayResponder(t *testing.T) {
+	initiator := NewBuilder(PortIdentity{ClockIdentity: 0x000af7fffe000001, PortNumber: 3}, 0)
+	responderID := PortIdentity{ClockIdentity: 0x000af7fffe000002, PortNumber: 1}
+	r := NewPDelayResponder(NewBuilder(responderID, 0))
+
+	initiator.PDelayReq()
+	req := initiator.PDelayReq()
+	req.SetCorrection(time.Microsecond)
+	t2 := time.Unix(1000, 100)
+	t3 := t2.Add(10 * time.Microsecond)
+
+	var tests = []struct {
+		desc string
+		m    Message
+		// correction expected in correctionField
+		correction time.Duration
+	}{
+		{desc: "Pdelay_Resp", m: r.Respond(req, t2)},
+		{desc: "Pdelay_Resp_Follow_Up", m: r.FollowUp(req, t3), correction: time.Microsecond},
+		{desc: "One-step Pdelay_Resp", m: r.RespondOneStep(req, t2, t3), correction: 11 * time.Microsecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := tt.m.Validate(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var h *Header
+			var requester PortIdentity
+			switch m := tt.m.(type) {
+			case *PDelRespMsg:
+				h, requester = &m.Header, PortIdentity{m.ClockIdentity, m.PortNumber}
+			case *PDelRespFollowUpMsg:
+				h, requester = &m.Header, PortIdentity{m.ClockIdentity, m.PortNumber}
+			}
+
+			if want, got := req.SourcePortIdentity(), requester; want != got {
+				t.Fatalf("unexpected requestingPortIdentity: %v != %v", want, got)
+			}
+
+			if want, got := responderID, h.SourcePortIdentity(); want != got {
+				t.Fatalf("unexpected sourcePortIdentity: %v != %v", want, got)
+			}
+
+			if want, got := req.SequenceID, h.SequenceID; want != got {
+				t.Fatalf("unexpected sequenceId: %v != %v", want, got)
+			}
+
+			if want, got := tt.correction, h.Correction(); want != got {
+				t.Fatalf("unexpected correction: %v != %v", want, got)
+			}
+		})
+	}
+}
@@ -12,48 +12,85 @@ type PDelReqMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *PDelReqMsg) MarshalBinary() ([]byte, error) {
 
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a PDelReqMsg in binary form.
+func (t *PDelReqMsg) MarshalLen() int {
+	return HeaderLen + PDelayReqPayloadLen
+}
+
+// AppendBinary appends the binary form of a PDelReqMsg to b.
+func (t *PDelReqMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a PDelReqMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *PDelReqMsg) MarshalTo(b []byte) (int, error) {
 	if t.Header.MessageType != PDelayReqMsgType {
-		return nil, ErrInvalidMsgType
+		return 0, ErrInvalidMsgType
 	}
 
 	if t.Header.MessageLength == 0 {
 		t.Header.MessageLength = HeaderLen + PDelayReqPayloadLen
 	}
 
-	b := make([]byte, HeaderLen+PDelayReqPayloadLen)
-
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
 	}
 
-	copy(b[:HeaderLen], headerSlice)
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 
 	// All the rest 20 bytes are reserved. Keep them zero values.
+	zero(b[HeaderLen:n])
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a PDelReqMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid PDelReqMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are ignored.
 func (t *PDelReqMsg) UnmarshalBinary(b []byte) error {
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
 
-	if len(b) != HeaderLen+PDelayReqPayloadLen {
-		return io.ErrUnexpectedEOF
+// unmarshal decodes a PDelReqMsg accordingly with the options.
+func (t *PDelReqMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, PDelayReqMsgType, HeaderLen+PDelayReqPayloadLen)
+	if err != nil {
+		return err
 	}
 
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
-	if err != nil {
+	if err = t.Header.unmarshal(b, o); err != nil {
 		return err
 	}
 
 	if t.Header.MessageType != PDelayReqMsgType {
-		return ErrInvalidMsgType
+		return decodeError(PDelayReqMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
 	}
 
 	// All the rest 20 bytes are reserved. Keep them zero values.
+	// The first 10 of them are originTimestamp which may be set by sender.
+	if err = o.reserved(&t.Header, b[HeaderLen+OriginTimestampFullLen:HeaderLen+PDelayReqPayloadLen], HeaderLen+OriginTimestampFullLen); err != nil {
+		return err
+	}
+
+	return o.trailer(b[HeaderLen+PDelayReqPayloadLen:], PDelayReqMsgType, HeaderLen+PDelayReqPayloadLen)
+}
 
-	return nil
+// Validate checks that a PDelReqMsg conforms to IEEE 1588.
+func (t *PDelReqMsg) Validate() error {
+	return t.Header.validate(PDelayReqMsgType, t.MarshalLen(), false)
 }
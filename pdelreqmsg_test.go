@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -128,6 +129,21 @@ func TestUnmarshalPDelReq(t *testing.T) {
 				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
 				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}),
 		},
+		{
+			desc: "Padded frame",
+			m: PDelReqMsg{
+				Header: Header{
+					MessageType:      PDelayReqMsgType,
+					MessageLength:    HeaderLen + PDelayReqPayloadLen,
+					VersionPTP:       Version2,
+					ClockIdentity:    0x000af7fffe42a753,
+					PortNumber:       2,
+					SequenceID:       55330,
+					LogMessagePeriod: -4,
+				},
+			},
+			b: pdelReqBytes,
+		},
 		{
 			desc: "Invalid length",
 			b: append([]byte{0x2, 0x2, 0x0, 0x36, 0x0, 0x0, 0x0, 0x0,
@@ -157,7 +173,7 @@ func TestUnmarshalPDelReq(t *testing.T) {
 			var m PDelReqMsg
 			err := m.UnmarshalBinary(tt.b)
 			if err != nil {
-				if want, got := tt.err, err; want != got {
+				if want, got := tt.err, err; !errors.Is(got, want) {
 					t.Fatalf("unexpected error: %v != %v", want, got)
 				}
 
@@ -17,18 +17,40 @@ type PDelRespFollowUpMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *PDelRespFollowUpMsg) MarshalBinary() ([]byte, error) {
 
-	if t.Header.MessageType != PDelayRespFollowUpMsgType {
-		return nil, ErrInvalidMsgType
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
 	}
 
-	b := make([]byte, HeaderLen+PDelayRespFollowUpPayloadLen)
+	return b, nil
+}
 
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+// MarshalLen returns the length of a PDelRespFollowUpMsg in binary form.
+func (t *PDelRespFollowUpMsg) MarshalLen() int {
+	return HeaderLen + PDelayRespFollowUpPayloadLen
+}
+
+// AppendBinary appends the binary form of a PDelRespFollowUpMsg to b.
+func (t *PDelRespFollowUpMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a PDelRespFollowUpMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *PDelRespFollowUpMsg) MarshalTo(b []byte) (int, error) {
+	if t.Header.MessageType != PDelayRespFollowUpMsgType {
+		return 0, ErrInvalidMsgType
 	}
 
-	copy(b[:HeaderLen], headerSlice)
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
+
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 	offset := HeaderLen
 
 	// Origin timestamp
@@ -40,30 +62,35 @@ func (t *PDelRespFollowUpMsg) MarshalBinary() ([]byte, error) {
 
 	binary.BigEndian.PutUint16(b[offset:offset+SourcePortNumberLen], t.PortNumber)
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a PDelRespFollowUpMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid PDelRespFollowUpMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are ignored.
 func (t *PDelRespFollowUpMsg) UnmarshalBinary(b []byte) error {
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
 
-	if len(b) != HeaderLen+PDelayRespFollowUpPayloadLen {
-		return io.ErrUnexpectedEOF
+// unmarshal decodes a PDelRespFollowUpMsg accordingly with the options.
+func (t *PDelRespFollowUpMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, PDelayRespFollowUpMsgType, HeaderLen+PDelayRespFollowUpPayloadLen)
+	if err != nil {
+		return err
 	}
 
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
-	if err != nil {
+	if err = t.Header.unmarshal(b, o); err != nil {
 		return err
 	}
 
 	if t.Header.MessageType != PDelayRespFollowUpMsgType {
-		return ErrInvalidMsgType
+		return decodeError(PDelayRespFollowUpMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
 	}
 
 	if t.OriginTimestamp, err = originTimestamp2Time(b[HeaderLen : HeaderLen+OriginTimestampFullLen]); err != nil {
-		return err
+		return decodeError(PDelayRespFollowUpMsgType, "responseOriginTimestamp", HeaderLen, nil, err)
 	}
 	offset := HeaderLen + OriginTimestampFullLen
 
@@ -71,6 +98,12 @@ func (t *PDelRespFollowUpMsg) UnmarshalBinary(b []byte) error {
 	offset += ClockIdentityLen
 
 	t.PortNumber = binary.BigEndian.Uint16(b[offset : offset+SourcePortNumberLen])
+	offset += SourcePortNumberLen
+
+	return o.trailer(b[offset:], PDelayRespFollowUpMsgType, offset)
+}
 
-	return nil
+// Validate checks that a PDelRespFollowUpMsg conforms to IEEE 1588.
+func (t *PDelRespFollowUpMsg) Validate() error {
+	return t.Header.validate(PDelayRespFollowUpMsgType, t.MarshalLen(), false)
 }
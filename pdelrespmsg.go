@@ -17,22 +17,44 @@ type PDelRespMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *PDelRespMsg) MarshalBinary() ([]byte, error) {
 
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a PDelRespMsg in binary form.
+func (t *PDelRespMsg) MarshalLen() int {
+	return HeaderLen + PDelayRespPayloadLen
+}
+
+// AppendBinary appends the binary form of a PDelRespMsg to b.
+func (t *PDelRespMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a PDelRespMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *PDelRespMsg) MarshalTo(b []byte) (int, error) {
 	if t.Header.MessageType != PDelayRespMsgType {
-		return nil, ErrInvalidMsgType
+		return 0, ErrInvalidMsgType
 	}
 
 	if t.Header.MessageLength == 0 {
 		t.Header.MessageLength = HeaderLen + PDelayRespPayloadLen
 	}
 
-	b := make([]byte, HeaderLen+PDelayRespPayloadLen)
-
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
 	}
 
-	copy(b[:HeaderLen], headerSlice)
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 	offset := HeaderLen
 
 	time2OriginTimestamp(t.ReceiveTimestamp, b[offset:offset+OriginTimestampFullLen])
@@ -43,30 +65,35 @@ func (t *PDelRespMsg) MarshalBinary() ([]byte, error) {
 
 	binary.BigEndian.PutUint16(b[offset:offset+2], t.PortNumber)
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a PDelRespMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid PDelRespMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are ignored.
 func (t *PDelRespMsg) UnmarshalBinary(b []byte) error {
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
 
-	if len(b) != HeaderLen+PDelayRespPayloadLen {
-		return io.ErrUnexpectedEOF
+// unmarshal decodes a PDelRespMsg accordingly with the options.
+func (t *PDelRespMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, PDelayRespMsgType, HeaderLen+PDelayRespPayloadLen)
+	if err != nil {
+		return err
 	}
 
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
-	if err != nil {
+	if err = t.Header.unmarshal(b, o); err != nil {
 		return err
 	}
 
 	if t.Header.MessageType != PDelayRespMsgType {
-		return ErrInvalidMsgType
+		return decodeError(PDelayRespMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
 	}
 
 	if t.ReceiveTimestamp, err = originTimestamp2Time(b[HeaderLen : HeaderLen+OriginTimestampFullLen]); err != nil {
-		return err
+		return decodeError(PDelayRespMsgType, "requestReceiptTimestamp", HeaderLen, nil, err)
 	}
 	offset := HeaderLen + OriginTimestampFullLen
 
@@ -74,6 +101,12 @@ func (t *PDelRespMsg) UnmarshalBinary(b []byte) error {
 	offset += ClockIdentityLen
 
 	t.PortNumber = binary.BigEndian.Uint16(b[offset : offset+SourcePortNumberLen])
+	offset += SourcePortNumberLen
+
+	return o.trailer(b[offset:], PDelayRespMsgType, offset)
+}
 
-	return nil
+// Validate checks that a PDelRespMsg conforms to IEEE 1588.
+func (t *PDelRespMsg) Validate() error {
+	return t.Header.validate(PDelayRespMsgType, t.MarshalLen(), false)
 }
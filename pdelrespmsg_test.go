@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -109,12 +110,38 @@ func TestUnmarshalPDelResp(t *testing.T) {
 				0x05, 0x7f, 0x00, 0x00, 0x4e, 0x37, 0x83, 0xfb, 0x05, 0x53, 0xf3, 0xe0, 0x00, 0x0c, 0x29, 0xff,
 				0xfe, 0x08, 0xe6, 0xe8, 0x00, 0x01}),
 		},
+		{
+			desc: "Padded frame",
+			m: PDelRespMsg{
+				Header: Header{
+					MessageType:   PDelayRespMsgType,
+					MessageLength: HeaderLen + PDelayReqPayloadLen,
+					VersionPTP:    Version2,
+					Flags: Flags{
+						TwoSteps: true,
+					},
+					ClockIdentity:    0x0023aefffe5d688b,
+					PortNumber:       1,
+					SequenceID:       6365,
+					LogMessagePeriod: 127,
+				},
+				ReceiveTimestamp: time.Unix(1312261115, 89388000),
+				ClockIdentity:    0x000c29fffe08e6e8,
+				PortNumber:       1,
+			},
+			b: append([]byte{0x3, 0x2, 0x0, 0x36, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x23, 0xae, 0xff, 0xfe, 0x5d, 0x68, 0x8b, 0x00, 0x01, 0x18, 0xdd,
+				0x05, 0x7f, 0x00, 0x00, 0x4e, 0x37, 0x83, 0xfb, 0x05, 0x53, 0xf3, 0xe0, 0x00, 0x0c, 0x29, 0xff,
+				0xfe, 0x08, 0xe6, 0xe8, 0x00, 0x01},
+				// Padding
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0),
+		},
 		{
 			desc: "Invalid length",
 			b: append([]byte{0x3, 0x2, 0x0, 0x36, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00, 0x00, 0x23, 0xae, 0xff, 0xfe, 0x5d, 0x68, 0x8b, 0x00, 0x01, 0x18, 0xdd,
 				0x05, 0x7f, 0x00, 0x00, 0x4e, 0x37, 0x83, 0xfb, 0x05, 0x53, 0xf3, 0xe0, 0x00, 0x0c, 0x29, 0xff,
-				0xfe, 0x08, 0xe6, 0xe8, 0x00, 0x01, 0x0}),
+				0xfe, 0x08, 0xe6, 0xe8, 0x00}),
 			err: io.ErrUnexpectedEOF,
 		},
 		{
@@ -132,7 +159,7 @@ func TestUnmarshalPDelResp(t *testing.T) {
 			var m PDelRespMsg
 			err := m.UnmarshalBinary(tt.b)
 			if err != nil {
-				if want, got := tt.err, err; want != got {
+				if want, got := tt.err, err; !errors.Is(got, want) {
 					t.Fatalf("unexpected error: %v != %v", want, got)
 				}
 
@@ -0,0 +1,115 @@
+//go:build linux && (amd64 || arm64)
+
+package ptp
+
+import (
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// Modes of clock_adjtime, linux/timex.h.
+const (
+	adjFrequency = 0x0002
+	adjSetOffset = 0x0100
+	adjNano      = 0x2000
+)
+
+// ptpClockGetCaps is the PTP_CLOCK_GETCAPS ioctl of linux/ptp_clock.h,
+// reading a struct ptp_clock_caps of 80 bytes.
+const ptpClockGetCaps = 0x80503d01
+
+// maxRealtimeAdjustment is the largest frequency adjustment of
+// CLOCK_REALTIME in ppb, 500 ppm
+const maxRealtimeAdjustment = 500000
+
+// PHC is a Linux PTP hardware clock or CLOCK_REALTIME, adjusted with
+// clock_adjtime.
+type PHC struct {
+	f      *os.File
+	id     int32
+	maxAdj float64
+}
+
+// OpenPHC opens the PTP hardware clock device at path, such as /dev/ptp0.
+func OpenPHC(path string) (*PHC, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var caps [20]int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), ptpClockGetCaps, uintptr(unsafe.Pointer(&caps))); errno != 0 {
+		f.Close()
+		return nil, &os.PathError{Op: "PTP_CLOCK_GETCAPS", Path: path, Err: errno}
+	}
+
+	return &PHC{
+		f: f,
+		// FD_TO_CLOCKID of linux/posix-timers.h
+		id:     int32(^f.Fd()<<3 | 3),
+		maxAdj: float64(caps[0]),
+	}, nil
+}
+
+// RealtimeClock returns the CLOCK_REALTIME system clock.
+func RealtimeClock() *PHC {
+	return &PHC{id: 0, maxAdj: maxRealtimeAdjustment}
+}
+
+// Close closes the device of the clock.
+func (c *PHC) Close() error {
+	if c.f == nil {
+		return nil
+	}
+	return c.f.Close()
+}
+
+// Now implements Clock.
+func (c *PHC) Now() (time.Time, error) {
+	var ts syscall.Timespec
+	if _, _, errno := syscall.Syscall(syscall.SYS_CLOCK_GETTIME, uintptr(c.id), uintptr(unsafe.Pointer(&ts)), 0); errno != 0 {
+		return time.Time{}, os.NewSyscallError("clock_gettime", errno)
+	}
+
+	return time.Unix(ts.Unix()), nil
+}
+
+// TimerNow implements TimerClock, the timers run on the monotonic time of
+// the system.
+func (c *PHC) TimerNow() time.Time {
+	return time.Now()
+}
+
+// AdjustFrequency implements Clock.
+func (c *PHC) AdjustFrequency(ppb float64) error {
+	// The frequency is in ppm with a 16 bit fractional part
+	return c.adjtime(&syscall.Timex{Modes: adjFrequency, Freq: int64(ppb * 65.536)})
+}
+
+// Step implements Clock.
+func (c *PHC) Step(d time.Duration) error {
+	// The nanoseconds of the offset are positive
+	sec, nsec := int64(d/time.Second), int64(d%time.Second)
+	if nsec < 0 {
+		sec, nsec = sec-1, nsec+int64(time.Second)
+	}
+
+	return c.adjtime(&syscall.Timex{
+		Modes: adjSetOffset | adjNano,
+		Time:  syscall.Timeval{Sec: sec, Usec: nsec},
+	})
+}
+
+// MaxAdjustment implements Clock.
+func (c *PHC) MaxAdjustment() float64 {
+	return c.maxAdj
+}
+
+func (c *PHC) adjtime(tx *syscall.Timex) error {
+	if _, _, errno := syscall.Syscall(sysClockAdjtime, uintptr(c.id), uintptr(unsafe.Pointer(tx)), 0); errno != 0 {
+		return os.NewSyscallError("clock_adjtime", errno)
+	}
+	return nil
+}
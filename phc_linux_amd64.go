@@ -0,0 +1,4 @@
+package ptp
+
+// sysClockAdjtime is the number of the clock_adjtime system call.
+const sysClockAdjtime = 305
@@ -0,0 +1,6 @@
+package ptp
+
+import "syscall"
+
+// sysClockAdjtime is the number of the clock_adjtime system call.
+const sysClockAdjtime = syscall.SYS_CLOCK_ADJTIME
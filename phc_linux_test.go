@@ -0,0 +1,32 @@
+//go:build linux && (amd64 || arm64)
+
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRealtimeClock(t *testing.T) {
+	c := RealtimeClock()
+	defer c.Close()
+
+	now, err := c.Now()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d := time.Since(now); abs(d) > time.Second {
+		t.Fatalf("unexpected time: %v", now)
+	}
+
+	if want, got := float64(maxRealtimeAdjustment), c.MaxAdjustment(); want != got {
+		t.Fatalf("unexpected max adjustment: %v != %v", want, got)
+	}
+}
+
+func TestOpenPHC(t *testing.T) {
+	if _, err := OpenPHC("/dev/null"); err == nil {
+		t.Fatal("expected error")
+	}
+}
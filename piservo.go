@@ -0,0 +1,92 @@
+package ptp
+
+import "time"
+
+// Default gains of PIServo, those of linuxptp for hardware timestamps and a
+// sync interval of 1 s.
+const (
+	DefaultPIKp = 0.7
+	DefaultPIKi = 0.3
+)
+
+// PIServo is a proportional-integral servo, as the pi servo of linuxptp. The
+// first two samples estimate the frequency of the clock, which is then
+// corrected by Kp*offset and the integral of Ki*offset.
+//
+// A PIServo is not safe for concurrent use.
+type PIServo struct {
+	ServoConfig
+	// Kp and Ki are the gains of a sample, in ppb/ns
+	Kp, Ki float64
+
+	lock   servoLock
+	count  int
+	offset time.Duration
+	ts     time.Time
+	// drift is the integral term, the frequency of the clock
+	drift    float64
+	lastFreq float64
+}
+
+// NewPIServo returns a PIServo of a clock adjusted by freq ppb.
+func NewPIServo(freq float64) *PIServo {
+	return &PIServo{
+		ServoConfig: DefaultServoConfig(),
+		Kp:          DefaultPIKp,
+		Ki:          DefaultPIKi,
+		drift:       freq,
+		lastFreq:    freq,
+	}
+}
+
+// Sample implements Servo.
+func (s *PIServo) Sample(offset time.Duration, ts time.Time) (float64, ServoState) {
+	state := ServoUnlocked
+	freq := s.lastFreq
+
+	switch s.count {
+	case 0:
+		s.offset, s.ts = offset, ts
+		s.count = 1
+
+	case 1:
+		dt := ts.Sub(s.ts)
+		if dt <= 0 {
+			s.count = 0
+			break
+		}
+
+		// The clock gained offset - s.offset in dt
+		s.drift, _ = s.clamp(s.drift - float64(offset-s.offset)/dt.Seconds())
+		freq = s.drift
+
+		state = ServoLocked
+		if s.lock.step(&s.ServoConfig, offset) {
+			state = ServoJump
+		}
+		s.count = 2
+
+	case 2:
+		if s.unlock(offset) {
+			s.count = 0
+			break
+		}
+
+		ki := s.Ki * float64(offset)
+		f, ok := s.clamp(s.drift - s.Kp*float64(offset) - ki)
+		if ok {
+			s.drift -= ki
+		}
+		freq = f
+		state = ServoLocked
+	}
+
+	s.lastFreq = freq
+
+	return freq, s.lock.update(&s.ServoConfig, state, offset)
+}
+
+// Reset implements Servo.
+func (s *PIServo) Reset() {
+	s.count = 0
+}
@@ -0,0 +1,247 @@
+package ptp
+
+import "time"
+
+// PortEvent is an event of the port state machine, IEEE 1588-2008 9.2.6.
+type PortEvent uint8
+
+const (
+	EventPowerUp PortEvent = iota
+	EventInitialize
+	// EventInitializeComplete ends INITIALIZING, the port has its
+	// transport and timestamping ready
+	EventInitializeComplete
+	EventDesignatedEnabled
+	EventDesignatedDisabled
+	EventFaultDetected
+	EventFaultCleared
+	EventStateDecision
+	EventAnnounceReceiptTimeoutExpires
+	EventQualificationTimeoutExpires
+	EventMasterClockSelected
+	// EventSynchronizationFault returns a SLAVE port to UNCALIBRATED, for
+	// example after a change of master
+	EventSynchronizationFault
+)
+
+var portEventNames = map[PortEvent]string{
+	EventPowerUp:                       "POWERUP",
+	EventInitialize:                    "INITIALIZE",
+	EventInitializeComplete:            "INITIALIZE_COMPLETE",
+	EventDesignatedEnabled:             "DESIGNATED_ENABLED",
+	EventDesignatedDisabled:            "DESIGNATED_DISABLED",
+	EventFaultDetected:                 "FAULT_DETECTED",
+	EventFaultCleared:                  "FAULT_CLEARED",
+	EventStateDecision:                 "STATE_DECISION_EVENT",
+	EventAnnounceReceiptTimeoutExpires: "ANNOUNCE_RECEIPT_TIMEOUT_EXPIRES",
+	EventQualificationTimeoutExpires:   "QUALIFICATION_TIMEOUT_EXPIRES",
+	EventMasterClockSelected:           "MASTER_CLOCK_SELECTED",
+	EventSynchronizationFault:          "SYNCHRONIZATION_FAULT",
+}
+
+// String returns the name of e.
+func (e PortEvent) String() string {
+	name, ok := portEventNames[e]
+	return enumString(name, ok, uint64(e))
+}
+
+// DefaultAnnounceReceiptTimeout is the default announceReceiptTimeout, in
+// announce intervals.
+const DefaultAnnounceReceiptTimeout = 3
+
+// PortStateMachine is the state machine of a port of an ordinary or boundary
+// clock, IEEE 1588-2008 figure 23. It runs the announce receipt and
+// qualification timers, which expire when Poll is called after their
+// deadline. The timers don't run while LogAnnounceInterval is one of the
+// special values.
+//
+// A PortStateMachine is not safe for concurrent use.
+type PortStateMachine struct {
+	// Clock runs the timers, time.Now if nil.
+	Clock Clock
+
+	LogAnnounceInterval    LogInterval
+	AnnounceReceiptTimeout uint8
+	// StepsRemoved is currentDS.stepsRemoved, PRE_MASTER qualifies for
+	// StepsRemoved+1 announce intervals.
+	StepsRemoved uint16
+	// SlaveOnly ports never become MASTER and return to LISTENING instead.
+	SlaveOnly bool
+
+	state                 PortState
+	announceDeadline      time.Time
+	qualificationDeadline time.Time
+}
+
+// NewPortStateMachine returns a PortStateMachine in state INITIALIZING with
+// timers based on logAnnounceInterval, running on clock.
+func NewPortStateMachine(logAnnounceInterval LogInterval, clock Clock) *PortStateMachine {
+	return &PortStateMachine{
+		Clock:                  clock,
+		LogAnnounceInterval:    logAnnounceInterval,
+		AnnounceReceiptTimeout: DefaultAnnounceReceiptTimeout,
+		state:                  Initializing,
+	}
+}
+
+// State returns the current state.
+func (m *PortStateMachine) State() PortState {
+	return m.state
+}
+
+// Handle applies event e and returns the new state. Events not defined for
+// the current state are ignored. EventStateDecision is applied with Decide.
+func (m *PortStateMachine) Handle(e PortEvent) PortState {
+	next := m.state
+	switch e {
+	case EventPowerUp, EventInitialize:
+		next = Initializing
+	case EventInitializeComplete:
+		if m.state == Initializing {
+			next = Listening
+		}
+	case EventDesignatedEnabled:
+		if m.state == Disabled {
+			next = Initializing
+		}
+	case EventDesignatedDisabled:
+		next = Disabled
+	case EventFaultDetected:
+		if m.state != Disabled {
+			next = Faulty
+		}
+	case EventFaultCleared:
+		if m.state == Faulty {
+			next = Initializing
+		}
+	case EventAnnounceReceiptTimeoutExpires:
+		switch m.state {
+		case Listening, Uncalibrated, Slave, Passive:
+			next = m.master()
+		}
+		// A slave-only port keeps listening for another interval
+		if next == Listening && m.state == Listening {
+			m.announceDeadline = m.deadline(int(m.AnnounceReceiptTimeout))
+		}
+	case EventQualificationTimeoutExpires:
+		if m.state == PreMaster {
+			next = Master
+		}
+	case EventMasterClockSelected:
+		if m.state == Uncalibrated {
+			next = Slave
+		}
+	case EventSynchronizationFault:
+		if m.state == Slave {
+			next = Uncalibrated
+		}
+	}
+
+	return m.enter(next)
+}
+
+// Decide applies the state decision d, STATE_DECISION_EVENT, and returns the
+// new state. A port in INITIALIZING, FAULTY or DISABLED ignores decisions.
+// M1 and M2 lead to MASTER directly, M3 qualifies the port in PRE_MASTER
+// first.
+//
+// A SLAVE port stays SLAVE after an S1 decision, a change of master is
+// signaled with EventSynchronizationFault.
+func (m *PortStateMachine) Decide(d StateDecision) PortState {
+	switch m.state {
+	case Initializing, Faulty, Disabled:
+		return m.state
+	}
+
+	next := m.state
+	switch d.Code {
+	case DecisionM1, DecisionM2:
+		next = m.master()
+	case DecisionM3:
+		if m.state != Master {
+			next = PreMaster
+		}
+		if m.SlaveOnly {
+			next = Listening
+		}
+	case DecisionP1, DecisionP2:
+		next = Passive
+	case DecisionS1:
+		if m.state != Slave {
+			next = Uncalibrated
+		}
+	}
+
+	return m.enter(next)
+}
+
+// AnnounceReceived restarts the announce receipt timer, on receipt of a
+// qualified Announce message of the foreign master or parent of the port.
+func (m *PortStateMachine) AnnounceReceived() {
+	if !m.announceDeadline.IsZero() {
+		m.announceDeadline = m.deadline(int(m.AnnounceReceiptTimeout))
+	}
+}
+
+// Deadline returns the time the next timer expires, zero if none runs.
+func (m *PortStateMachine) Deadline() time.Time {
+	if !m.qualificationDeadline.IsZero() {
+		return m.qualificationDeadline
+	}
+	return m.announceDeadline
+}
+
+// Poll expires the timers whose deadline passed and returns the new state.
+func (m *PortStateMachine) Poll() PortState {
+	now := timerNow(m.Clock)
+
+	if d := m.qualificationDeadline; !d.IsZero() && !now.Before(d) {
+		m.Handle(EventQualificationTimeoutExpires)
+	}
+
+	if d := m.announceDeadline; !d.IsZero() && !now.Before(d) {
+		m.Handle(EventAnnounceReceiptTimeoutExpires)
+	}
+
+	return m.state
+}
+
+// master returns the state of a port recommended MASTER.
+func (m *PortStateMachine) master() PortState {
+	if m.SlaveOnly {
+		return Listening
+	}
+	return Master
+}
+
+// deadline returns the time n announce intervals from now, zero if
+// LogAnnounceInterval is one of the special values.
+func (m *PortStateMachine) deadline(n int) time.Time {
+	d, ok := m.LogAnnounceInterval.Interval()
+	if !ok {
+		return time.Time{}
+	}
+
+	return timerNow(m.Clock).Add(time.Duration(n) * d)
+}
+
+// enter changes the state to next and starts the timers of next. Timers keep
+// running while the state does not change.
+func (m *PortStateMachine) enter(next PortState) PortState {
+	if next == m.state {
+		return next
+	}
+	m.state = next
+
+	m.announceDeadline = time.Time{}
+	m.qualificationDeadline = time.Time{}
+
+	switch next {
+	case Listening, Uncalibrated, Slave, Passive:
+		m.announceDeadline = m.deadline(int(m.AnnounceReceiptTimeout))
+	case PreMaster:
+		m.qualificationDeadline = m.deadline(int(m.StepsRemoved) + 1)
+	}
+
+	return next
+}
@@ -0,0 +1,147 @@
+package ptp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPortStateMachine(t *testing.T) {
+	type step struct {
+		desc string
+		do   func(m *PortStateMachine, clock *SimClock)
+		want PortState
+	}
+
+	event := func(e PortEvent) func(m *PortStateMachine, clock *SimClock) {
+		return func(m *PortStateMachine, clock *SimClock) { m.Handle(e) }
+	}
+
+	decide := func(code StateDecisionCode) func(m *PortStateMachine, clock *SimClock) {
+		return func(m *PortStateMachine, clock *SimClock) { m.Decide(StateDecision{Code: code}) }
+	}
+
+	// wait advances the clock by d and polls the timers
+	wait := func(d time.Duration) func(m *PortStateMachine, clock *SimClock) {
+		return func(m *PortStateMachine, clock *SimClock) {
+			clock.Advance(d)
+			m.Poll()
+		}
+	}
+
+	announce := func(m *PortStateMachine, clock *SimClock) { m.AnnounceReceived() }
+
+	listening := []step{
+		{"POWERUP", event(EventPowerUp), Initializing},
+		{"Initialized", event(EventInitializeComplete), Listening},
+	}
+
+	var tests = []struct {
+		desc                string
+		slaveOnly           bool
+		logAnnounceInterval LogInterval
+		steps               []step
+	}{
+		{
+			desc: "Grandmaster",
+			steps: append(listening,
+				step{"Announce receipt timeout running", wait(2999 * time.Millisecond), Listening},
+				step{"Announce receipt timeout", wait(time.Millisecond), Master},
+				step{"Better foreign master", decide(DecisionS1), Uncalibrated},
+				step{"Synchronized", event(EventMasterClockSelected), Slave},
+				step{"Local clock better", decide(DecisionM2), Master},
+			),
+		},
+		{
+			desc: "Slave",
+			steps: append(listening,
+				step{"Foreign master qualified", decide(DecisionS1), Uncalibrated},
+				step{"Synchronized", event(EventMasterClockSelected), Slave},
+				step{"Announce received", wait(2 * time.Second), Slave},
+				step{"Timer restarted", announce, Slave},
+				step{"Announce received again", wait(2 * time.Second), Slave},
+				step{"Still slave", decide(DecisionS1), Slave},
+				step{"Master changed", event(EventSynchronizationFault), Uncalibrated},
+				step{"Master lost", wait(3 * time.Second), Master},
+			),
+		},
+		{
+			desc: "Boundary clock port qualifying",
+			steps: append(listening,
+				step{"Master of a downstream clock", decide(DecisionM3), PreMaster},
+				step{"Qualification running", wait(1999 * time.Millisecond), PreMaster},
+				step{"Qualified", wait(time.Millisecond), Master},
+				step{"Still master", decide(DecisionM3), Master},
+				step{"Passive", decide(DecisionP2), Passive},
+				step{"No Announce on passive port", wait(3 * time.Second), Master},
+			),
+		},
+		{
+			desc: "Passive in PRE_MASTER",
+			steps: append(listening,
+				step{"Master of a downstream clock", decide(DecisionM3), PreMaster},
+				step{"Passive", decide(DecisionP1), Passive},
+				step{"Qualification timer stopped", wait(2 * time.Second), Passive},
+			),
+		},
+		{
+			desc: "Fault",
+			steps: append(listening,
+				step{"Ignored event", event(EventMasterClockSelected), Listening},
+				step{"Fault", event(EventFaultDetected), Faulty},
+				step{"Decision ignored", decide(DecisionM2), Faulty},
+				step{"No timers", wait(time.Hour), Faulty},
+				step{"Cleared", event(EventFaultCleared), Initializing},
+				step{"Initialized", event(EventInitializeComplete), Listening},
+			),
+		},
+		{
+			desc: "Disabled",
+			steps: append(listening,
+				step{"Disabled", event(EventDesignatedDisabled), Disabled},
+				step{"No fault when disabled", event(EventFaultDetected), Disabled},
+				step{"Enabled", event(EventDesignatedEnabled), Initializing},
+				step{"Initialized", event(EventInitializeComplete), Listening},
+				step{"INITIALIZE", event(EventInitialize), Initializing},
+			),
+		},
+		{
+			desc:      "Slave-only",
+			slaveOnly: true,
+			steps: append(listening,
+				step{"Announce receipt timeout", wait(3 * time.Second), Listening},
+				step{"Timer restarted", wait(3 * time.Second), Listening},
+				step{"Foreign master qualified", decide(DecisionS1), Uncalibrated},
+				step{"Local clock better", decide(DecisionM2), Listening},
+				step{"Recommended master of another port", decide(DecisionM3), Listening},
+			),
+		},
+		{
+			desc:                "Special announce interval",
+			logAnnounceInterval: LogIntervalInitial,
+			steps: append(listening,
+				step{"No announce receipt timeout", wait(time.Hour), Listening},
+				step{"Announce received", announce, Listening},
+				step{"Still no announce receipt timeout", wait(time.Hour), Listening},
+				step{"Master of a downstream clock", decide(DecisionM3), PreMaster},
+				step{"No qualification timeout", wait(time.Hour), PreMaster},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			clock := NewSimClock(time.Unix(1000, 0), 0, 0, 1)
+			m := NewPortStateMachine(tt.logAnnounceInterval, clock)
+			// PRE_MASTER qualifies in 2 announce intervals
+			m.StepsRemoved = 1
+			m.SlaveOnly = tt.slaveOnly
+
+			for _, s := range tt.steps {
+				s.do(m, clock)
+				if want, got := s.want, m.State(); want != got {
+					t.Fatalf("%s: unexpected state: %v != %v", s.desc, want, got)
+				}
+			}
+		})
+	}
+}
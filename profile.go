@@ -0,0 +1,49 @@
+package ptp
+
+// Profile identifies the PTP profile a node operates in. Some fields, such as
+// clockClass, have a meaning that depends on the profile.
+type Profile uint8
+
+// Supported profiles
+const (
+	// DefaultProfile is the IEEE 1588 default delay request-response and
+	// peer-to-peer profile.
+	DefaultProfile Profile = iota
+	// TelecomFrequencyProfile is the ITU-T G.8265.1 frequency profile.
+	TelecomFrequencyProfile
+	// TelecomPhaseProfile is the ITU-T G.8275.1 full timing support profile.
+	TelecomPhaseProfile
+	// TelecomPartialTimingProfile is the ITU-T G.8275.2 partial timing
+	// support profile.
+	TelecomPartialTimingProfile
+)
+
+// isValidClockClassForProfile reports whether the clockClass may be advertised
+// by a clock operating in the given profile.
+func isValidClockClassForProfile(c ClockClassType, p Profile) bool {
+	switch p {
+	case TelecomFrequencyProfile:
+		// Quality levels of G.781 options I and II mapped into clockClass
+		switch c {
+		case 80, 82, 84, 86, 90, 96, 100, 102, 104, 106, 108, 110:
+			return true
+		}
+		return false
+	case TelecomPhaseProfile, TelecomPartialTimingProfile:
+		switch c {
+		case
+			PrimarySyncRefClass,
+			LostSyncClass,
+			TelecomHoldoverInSpecClass,
+			TelecomGMHoldoverCategory1Class,
+			TelecomGMHoldoverCategory2Class,
+			TelecomGMHoldoverCategory3Class,
+			TelecomHoldoverOutOfSpecClass,
+			DefaultClass,
+			SlaveOnlyClass:
+			return true
+		}
+		return false
+	}
+	return c.Category() != ClockClassReserved
+}
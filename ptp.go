@@ -31,6 +31,19 @@ var (
 	ErrInvalidTlvType       = errors.New("Invalid TLV type")
 	ErrInvalidTlvOrgId      = errors.New("Invalid TLV organizationId")
 	ErrInvalidTlvOrgSubType = errors.New("Invalid organization sub type")
+	ErrReservedNotZero      = errors.New("Reserved field is not zero")
+	ErrInvalidControlField  = errors.New("Invalid control field")
+	ErrInvalidMsgLength     = errors.New("Invalid message length")
+	ErrInvalidTwoStepFlag   = errors.New("Invalid twoStep flag")
+	ErrInvalidLogInterval   = errors.New("Invalid log message interval")
+	ErrInvalidStepsRemoved  = errors.New("Invalid steps removed")
+	ErrInvalidGMIdentity    = errors.New("Invalid grandmaster identity")
+	ErrOutOfRange           = errors.New("Value out of range")
+	ErrNotEnoughSamples     = errors.New("Not enough samples")
+	ErrSelfAnnounce         = errors.New("Announce message sent by the receiving port")
+	ErrDuplicateDataset     = errors.New("Data sets of the same Announce message")
+	ErrUnknownServo         = errors.New("Unknown servo type")
+	ErrUnknownPort          = errors.New("Unknown port number")
 )
 
 // MsgType Type
@@ -50,6 +63,14 @@ const (
 	MgmtMsgType               MsgType = 0xD
 )
 
+// Message is implemented by all PTP messages.
+type Message interface {
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(b []byte) error
+	MarshalLen() int
+	Validate() error
+}
+
 // MsgCtrlType Control Type
 type MsgCtrlType uint8
 
@@ -59,6 +80,7 @@ const (
 	DelayReqMsgCtrlType  MsgCtrlType = 1
 	FollowUpMsgCtrlType  MsgCtrlType = 2
 	DelayRespMsgCtrlType MsgCtrlType = 3
+	MgmtMsgCtrlType      MsgCtrlType = 4
 	OtherMsgCtrlType     MsgCtrlType = 5
 )
 
@@ -130,10 +152,22 @@ const (
 	PDelayRespFollowUpPayloadLen = OriginTimestampFullLen + PortIdentityLen
 	AnnouncePayloadLen           = 30
 	SignalingPayloadLen          = 10
+	MgmtPayloadLen               = PortIdentityLen + 4
 	ClockQualityPayloadLen       = 4
 	// SignalingPayloadLen depends on TLVs
 )
 
+// PortIdentity identifies a PTP port.
+type PortIdentity struct {
+	ClockIdentity uint64
+	PortNumber    uint16
+}
+
+// String formats the PortIdentity as in "000af7.fffe.42a753-1".
+func (p PortIdentity) String() string {
+	return formatPortIdentity(p.ClockIdentity, p.PortNumber)
+}
+
 // TimeSourceType Type
 type TimeSourceType uint8
 
@@ -173,15 +207,8 @@ func time2OriginTimestamp(t time.Time, b []byte) error {
 		return io.ErrUnexpectedEOF
 	}
 
-	sec := t.Unix()
-	nanosec := t.UnixNano()
-
-	secHexSlice := make([]byte, 8)
-
-	binary.BigEndian.PutUint64(secHexSlice, uint64(sec))
-	binary.BigEndian.PutUint32(b[6:], uint32(nanosec-sec*1000000000))
-
-	copy(b[:6], secHexSlice[2:])
+	putUint48(b[:6], uint64(t.Unix()))
+	binary.BigEndian.PutUint32(b[6:], uint32(t.Nanosecond()))
 
 	return nil
 }
@@ -193,15 +220,50 @@ func originTimestamp2Time(b []byte) (time.Time, error) {
 		return time.Now(), io.ErrUnexpectedEOF
 	}
 
-	sec := binary.BigEndian.Uint64(append([]byte{0, 0}, b[:6]...))
-
-	nsecSlice := append([]byte{}, b[6:10]...)
-	nsecSlice = append(nsecSlice, []byte{0, 0, 0, 0}...)
-	nsec := binary.BigEndian.Uint32(nsecSlice)
+	sec := uint48(b[:6])
+	nsec := binary.BigEndian.Uint32(b[6:10])
 
 	return time.Unix(int64(sec), int64(nsec)), nil
 }
 
+// putUint48 stores the lowest 48 bits of v into b in big endian order.
+func putUint48(b []byte, v uint64) {
+	_ = b[5]
+	b[0] = byte(v >> 40)
+	b[1] = byte(v >> 32)
+	binary.BigEndian.PutUint32(b[2:6], uint32(v))
+}
+
+// uint48 returns 48 bit big endian value of b.
+func uint48(b []byte) uint64 {
+	_ = b[5]
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(binary.BigEndian.Uint32(b[2:6]))
+}
+
+// zero clears b, so that reserved fields of a reused buffer are sent as zeros.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// appendMarshal extends b by n bytes and marshals into the extension using
+// marshalTo. b is reallocated only if its capacity is not enough.
+func appendMarshal(b []byte, n int, marshalTo func([]byte) (int, error)) ([]byte, error) {
+	l := len(b)
+	if cap(b)-l < n {
+		nb := make([]byte, l, 2*cap(b)+n)
+		copy(nb, b)
+		b = nb
+	}
+
+	if _, err := marshalTo(b[l : l+n]); err != nil {
+		return b[:l], err
+	}
+
+	return b[:l+n], nil
+}
+
 const UScaledNsLen = 12
 
 type UScaledNs struct {
@@ -224,11 +286,30 @@ func NewUScaledNs(b []byte) (UScaledNs, error) {
 func (p *UScaledNs) MarshalBinary() ([]byte, error) {
 	b := make([]byte, UScaledNsLen)
 
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo marshals a UScaledNs into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *UScaledNs) MarshalTo(b []byte) (int, error) {
+	if len(b) < UScaledNsLen {
+		return 0, io.ErrShortBuffer
+	}
+
 	binary.BigEndian.PutUint32(b[:4], uint32(p.ms))
 
-	binary.BigEndian.PutUint64(b[4:], p.ls)
+	binary.BigEndian.PutUint64(b[4:UScaledNsLen], p.ls)
 
-	return b, nil
+	return UScaledNsLen, nil
+}
+
+// AppendBinary appends the binary form of a UScaledNs to b.
+func (p *UScaledNs) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, UScaledNsLen, p.MarshalTo)
 }
 
 // UnmarshalBinary unmarshals a byte slice into a UScaledNs.
@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"bytes"
+	"io"
 	"reflect"
 	"testing"
 	"time"
@@ -75,3 +76,118 @@ func TestOriginTimestamp2Time(t *testing.T) {
 		})
 	}
 }
+
+type appendMarshaler interface {
+	MarshalBinary() ([]byte, error)
+	AppendBinary(b []byte) ([]byte, error)
+	MarshalTo(b []byte) (int, error)
+}
+
+func marshalers() []struct {
+	desc string
+	m    appendMarshaler
+} {
+	header := Header{
+		ClockIdentity:    0x000af7fffe42a753,
+		PortNumber:       2,
+		SequenceID:       55330,
+		LogMessagePeriod: -4,
+	}
+	withType := func(t MsgType) Header {
+		h := header
+		h.MessageType = t
+		return h
+	}
+
+	return []struct {
+		desc string
+		m    appendMarshaler
+	}{
+		{desc: "Header", m: &Header{MessageType: SyncMsgType, CorrectionNs: 1000, CorrectionSubNs: 0x8000}},
+		{desc: "ClockQuality", m: ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracy1ms}},
+		{desc: "UScaledNs", m: &UScaledNs{1, 2}},
+		{desc: "SyncMsg", m: &SyncMsg{Header: withType(SyncMsgType), OriginTimestamp: time.Unix(500, 200)}},
+		{desc: "DelReqMsg", m: &DelReqMsg{Header: withType(DelayReqMsgType), OriginTimestamp: time.Unix(500, 200)}},
+		{desc: "FollowUpMsg", m: &FollowUpMsg{Header: withType(FollowUpMsgType), PreciseOriginTimestamp: time.Unix(500, 200)}},
+		{desc: "DelRespMsg", m: &DelRespMsg{Header: withType(DelayRespMsgType), ReceiveTimestamp: time.Unix(500, 200), RequestingPortIdentity: 1, RequestingPortID: 2}},
+		{desc: "PDelReqMsg", m: &PDelReqMsg{Header: withType(PDelayReqMsgType)}},
+		{desc: "PDelRespMsg", m: &PDelRespMsg{Header: withType(PDelayRespMsgType), ReceiveTimestamp: time.Unix(500, 200)}},
+		{desc: "PDelRespFollowUpMsg", m: &PDelRespFollowUpMsg{Header: withType(PDelayRespFollowUpMsgType), OriginTimestamp: time.Unix(500, 200)}},
+		{desc: "AnnounceMsg", m: AnnounceMsg{
+			Header:           withType(AnnounceMsgType),
+			GMClockQuality:   ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy100ns},
+			TimeSource:       TimeSourceGPS,
+			PathTraceTlv:     PathTraceTlv{pathSequence: []uint64{1, 2}},
+			PowerProfile:     &PowerProfileTlv{GrandmasterID: 1},
+			PowerProfile2011: &PowerProfile2011Tlv{GrandmasterID: 1},
+		}},
+		{desc: "SignalingMsg", m: &SignalingMsg{Header: withType(SignalingMsgType), IntervalRequestTlv: IntervalRequestTlv{AnnounceInterval: 1}}},
+		{desc: "MgmtMsg", m: MgmtMsg{Header: withType(MgmtMsgType), ActionField: Command, SmpteSync: &SmpteSyncTlv{DefaultSystemFrameRate: FrameRate25}}},
+		{desc: "PathTraceTlv", m: &PathTraceTlv{pathSequence: []uint64{1, 2, 3}}},
+		{desc: "IntervalRequestTlv", m: &IntervalRequestTlv{TimeSyncInterval: -3}},
+		{desc: "FollowUpTlv", m: &FollowUpTlv{GmTimeBaseIndicator: 1, LastGmPhaseChange: UScaledNs{1, 2}}},
+		{desc: "CsnTlv", m: &CsnTlv{NeighborRateRatio: 1, NeighborPropDelay: UScaledNs{0, 3}}},
+		{desc: "PowerProfileTlv", m: &PowerProfileTlv{GrandmasterID: 1, TotalTimeInaccuracy: 2}},
+		{desc: "PowerProfile2011Tlv", m: &PowerProfile2011Tlv{GrandmasterID: 1, NetworkTimeInaccuracy: 2}},
+		{desc: "SmpteSyncTlv", m: &SmpteSyncTlv{DefaultSystemFrameRate: FrameRate50, CurrentLocalOffset: time.Hour}},
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	for _, tt := range marshalers() {
+		t.Run(tt.desc, func(t *testing.T) {
+			want, err := tt.m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Reused buffers must not leak garbage into reserved fields
+			dirty := bytes.Repeat([]byte{0xa5}, len(want)+3)
+
+			n, err := tt.m.MarshalTo(dirty)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := dirty[:n]; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected MarshalTo bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+
+			prefix := []byte{0xde, 0xad}
+			got, err := tt.m.AppendBinary(prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want := append(append([]byte{}, prefix...), want...); !bytes.Equal(want, got) {
+				t.Fatalf("unexpected AppendBinary bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+
+			if _, err := tt.m.MarshalTo(make([]byte, len(want)-1)); err != io.ErrShortBuffer {
+				t.Fatalf("unexpected error: %v != %v", io.ErrShortBuffer, err)
+			}
+
+			buf := make([]byte, 0, len(want))
+			allocs := testing.AllocsPerRun(100, func() {
+				if _, err := tt.m.AppendBinary(buf[:0]); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			})
+			if allocs != 0 {
+				t.Fatalf("unexpected allocations per AppendBinary: %v", allocs)
+			}
+		})
+	}
+}
+
+func BenchmarkAppendBinary(b *testing.B) {
+	for _, bb := range marshalers() {
+		b.Run(bb.desc, func(b *testing.B) {
+			buf := make([]byte, 0, 1500)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bb.m.AppendBinary(buf[:0])
+			}
+		})
+	}
+}
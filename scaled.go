@@ -0,0 +1,100 @@
+package ptp
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// scaledNsShift is the number of fraction bits of UScaledNs
+const scaledNsShift = 16
+
+// rateRatioShift is the number of fraction bits of scaled rate offsets
+const rateRatioShift = 41
+
+var maxUScaledNs = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(1))
+
+// UScaledNsFromBig returns the UScaledNs of v nanoseconds multiplied by 2^16.
+// If v is negative or does not fit 96 bits, ErrOutOfRange is returned.
+func UScaledNsFromBig(v *big.Int) (UScaledNs, error) {
+	if v.Sign() < 0 || v.Cmp(maxUScaledNs) > 0 {
+		return UScaledNs{}, ErrOutOfRange
+	}
+
+	ls := new(big.Int).And(v, new(big.Int).SetUint64(math.MaxUint64))
+
+	return UScaledNs{
+		ms: int32(uint32(new(big.Int).Rsh(v, 64).Uint64())),
+		ls: ls.Uint64(),
+	}, nil
+}
+
+// Big returns the value in nanoseconds multiplied by 2^16.
+func (p UScaledNs) Big() *big.Int {
+	v := new(big.Int).SetUint64(uint64(uint32(p.ms)))
+	v.Lsh(v, 64)
+
+	return v.Or(v, new(big.Int).SetUint64(p.ls))
+}
+
+// UScaledNsFromFloat returns the UScaledNs of ns nanoseconds, truncated to
+// 2^-16 ns. If ns is negative, not finite or too large, ErrOutOfRange is
+// returned.
+func UScaledNsFromFloat(ns float64) (UScaledNs, error) {
+	if ns < 0 || math.IsNaN(ns) || math.IsInf(ns, 0) {
+		return UScaledNs{}, ErrOutOfRange
+	}
+
+	v, _ := big.NewFloat(math.Ldexp(ns, scaledNsShift)).Int(nil)
+
+	return UScaledNsFromBig(v)
+}
+
+// Float returns the value in nanoseconds. Values above 2^53 ns lose precision.
+func (p UScaledNs) Float() float64 {
+	return math.Ldexp(float64(uint32(p.ms)), 64-scaledNsShift) + math.Ldexp(float64(p.ls), -scaledNsShift)
+}
+
+// UScaledNsFromDuration returns the UScaledNs of d. If d is negative,
+// ErrOutOfRange is returned.
+func UScaledNsFromDuration(d time.Duration) (UScaledNs, error) {
+	if d < 0 {
+		return UScaledNs{}, ErrOutOfRange
+	}
+
+	return UScaledNsFromBig(new(big.Int).Lsh(big.NewInt(int64(d)), scaledNsShift))
+}
+
+// Duration returns the value truncated to nanoseconds. If it does not fit a
+// time.Duration, ErrOutOfRange is returned.
+func (p UScaledNs) Duration() (time.Duration, error) {
+	v := p.Big()
+	v.Rsh(v, scaledNsShift)
+	if !v.IsInt64() {
+		return 0, ErrOutOfRange
+	}
+
+	return time.Duration(v.Int64()), nil
+}
+
+// RateRatio is the ratio of the frequencies of two clocks, such as
+// neighborRateRatio or the rateRatio of 802.1AS.
+type RateRatio float64
+
+// RateRatioFromScaled returns the RateRatio of a scaled rate offset, which is
+// the rate ratio minus 1 multiplied by 2^41, as carried in
+// cumulativeScaledRateOffset, scaledLastGmFreqChange and neighborRateRatio.
+func RateRatioFromScaled(scaled int32) RateRatio {
+	return RateRatio(1 + math.Ldexp(float64(scaled), -rateRatioShift))
+}
+
+// Scaled returns the scaled rate offset of r, rounded to the nearest value.
+// If it does not fit an int32, ErrOutOfRange is returned.
+func (r RateRatio) Scaled() (int32, error) {
+	v := math.Round(math.Ldexp(float64(r)-1, rateRatioShift))
+	if math.IsNaN(v) || v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, ErrOutOfRange
+	}
+
+	return int32(v), nil
+}
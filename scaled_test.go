@@ -0,0 +1,129 @@
+package ptp
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestUScaledNs(t *testing.T) {
+	var tests = []struct {
+		desc string
+		p    UScaledNs
+		big  string
+		ns   float64
+		d    time.Duration
+		derr error
+	}{
+		{desc: "Zero", p: UScaledNs{}, big: "0", ns: 0, d: 0},
+		{desc: "Fraction", p: UScaledNs{ls: 0x18000}, big: "98304", ns: 1.5, d: 1},
+		{desc: "1s", p: UScaledNs{ls: 1e9 << 16}, big: "65536000000000", ns: 1e9, d: time.Second},
+		{desc: "Most significant bits", p: UScaledNs{ms: 1}, big: "18446744073709551616", ns: math.Ldexp(1, 48), d: 1 << 48},
+		{desc: "Largest", p: UScaledNs{ms: -1, ls: math.MaxUint64}, big: "79228162514264337593543950335",
+			ns: math.Ldexp(1, 80), derr: ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.big, tt.p.Big().String(); want != got {
+				t.Fatalf("unexpected big: %v != %v", want, got)
+			}
+
+			v, _ := new(big.Int).SetString(tt.big, 10)
+			p, err := UScaledNsFromBig(v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want, got := tt.p, p; want != got {
+				t.Fatalf("unexpected value: %v != %v", want, got)
+			}
+
+			if want, got := tt.ns, tt.p.Float(); want != got {
+				t.Fatalf("unexpected float: %v != %v", want, got)
+			}
+
+			d, err := tt.p.Duration()
+			if want, got := tt.derr, err; want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+			if want, got := tt.d, d; want != got {
+				t.Fatalf("unexpected duration: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestUScaledNsFrom(t *testing.T) {
+	var tests = []struct {
+		desc string
+		fn   func() (UScaledNs, error)
+		p    UScaledNs
+		err  error
+	}{
+		{desc: "Float", fn: func() (UScaledNs, error) { return UScaledNsFromFloat(1.5) }, p: UScaledNs{ls: 0x18000}},
+		{desc: "Float truncated", fn: func() (UScaledNs, error) { return UScaledNsFromFloat(1.0 / 3) }, p: UScaledNs{ls: 0x5555}},
+		{desc: "Float of 2^64 ns", fn: func() (UScaledNs, error) { return UScaledNsFromFloat(math.Ldexp(1, 64)) }, p: UScaledNs{ms: 1 << 16}},
+		{desc: "Negative float", fn: func() (UScaledNs, error) { return UScaledNsFromFloat(-1) }, err: ErrOutOfRange},
+		{desc: "NaN", fn: func() (UScaledNs, error) { return UScaledNsFromFloat(math.NaN()) }, err: ErrOutOfRange},
+		{desc: "Float too large", fn: func() (UScaledNs, error) { return UScaledNsFromFloat(math.Ldexp(1, 80)) }, err: ErrOutOfRange},
+		{desc: "Duration", fn: func() (UScaledNs, error) { return UScaledNsFromDuration(time.Second) }, p: UScaledNs{ls: 1e9 << 16}},
+		{desc: "Largest duration", fn: func() (UScaledNs, error) { return UScaledNsFromDuration(math.MaxInt64) },
+			p: UScaledNs{ms: 0x7fff, ls: 0xffffffffffff0000}},
+		{desc: "Negative duration", fn: func() (UScaledNs, error) { return UScaledNsFromDuration(-1) }, err: ErrOutOfRange},
+		{desc: "Negative big", fn: func() (UScaledNs, error) { return UScaledNsFromBig(big.NewInt(-1)) }, err: ErrOutOfRange},
+		{desc: "Big too large", fn: func() (UScaledNs, error) { return UScaledNsFromBig(new(big.Int).Lsh(big.NewInt(1), 96)) },
+			err: ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p, err := tt.fn()
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+
+			if want, got := tt.p, p; want != got {
+				t.Fatalf("unexpected value: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestRateRatio(t *testing.T) {
+	var tests = []struct {
+		desc   string
+		r      RateRatio
+		scaled int32
+		err    error
+	}{
+		{desc: "Equal rates", r: 1, scaled: 0},
+		{desc: "Faster", r: RateRatio(1 + math.Ldexp(1, -20)), scaled: 1 << 21},
+		{desc: "Slower", r: RateRatio(1 - math.Ldexp(1, -41)), scaled: -1},
+		{desc: "Largest", r: RateRatio(1 + math.Ldexp(math.MaxInt32, -41)), scaled: math.MaxInt32},
+		{desc: "Smallest", r: RateRatio(1 + math.Ldexp(math.MinInt32, -41)), scaled: math.MinInt32},
+		{desc: "Too fast", r: 1.001, err: ErrOutOfRange},
+		{desc: "Too slow", r: 0.999, err: ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			scaled, err := tt.r.Scaled()
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+
+			if want, got := tt.scaled, scaled; want != got {
+				t.Fatalf("unexpected scaled offset: %v != %v", want, got)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if want, got := tt.r, RateRatioFromScaled(scaled); want != got {
+				t.Fatalf("unexpected rate ratio: %v != %v", want, got)
+			}
+		})
+	}
+}
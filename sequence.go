@@ -0,0 +1,143 @@
+package ptp
+
+// SequenceStatus is the outcome of tracking the sequenceId of a message.
+type SequenceStatus uint8
+
+const (
+	// SequenceFirst is the first message tracked from the source
+	SequenceFirst SequenceStatus = iota
+	// SequenceInOrder directly follows the previous message
+	SequenceInOrder
+	// SequenceGap follows the previous message after lost messages
+	SequenceGap
+	// SequenceDuplicate was already received
+	SequenceDuplicate
+	// SequenceOutOfOrder is older than the previous message and was not
+	// received before, it arrived late or was reordered
+	SequenceOutOfOrder
+)
+
+var sequenceStatusNames = map[SequenceStatus]string{
+	SequenceFirst:      "first",
+	SequenceInOrder:    "inOrder",
+	SequenceGap:        "gap",
+	SequenceDuplicate:  "duplicate",
+	SequenceOutOfOrder: "outOfOrder",
+}
+
+// String returns the name of s.
+func (s SequenceStatus) String() string {
+	name, ok := sequenceStatusNames[s]
+	return enumString(name, ok, uint64(s))
+}
+
+// Stale reports whether the message is older than or the same as the last
+// message, for example a Follow_Up that must not be matched with the
+// current Sync.
+func (s SequenceStatus) Stale() bool {
+	return s == SequenceDuplicate || s == SequenceOutOfOrder
+}
+
+// SequenceCounters counts the messages tracked from a source.
+type SequenceCounters struct {
+	Received   uint64
+	Lost       uint64
+	Duplicated uint64
+	OutOfOrder uint64
+}
+
+// sequenceWindow is the number of recent sequenceIds remembered to tell
+// duplicates from late messages.
+const sequenceWindow = 64
+
+type sequenceKey struct {
+	source  PortIdentity
+	msgType MsgType
+}
+
+type sequenceState struct {
+	last uint16
+	// seen has bit i set if sequenceId last-i was received
+	seen     uint64
+	counters SequenceCounters
+}
+
+// SequenceTracker tracks the sequenceIds of messages per source port and
+// message type. sequenceIds are compared with wraparound, an id up to 2^15
+// after the last one is newer.
+//
+// A SequenceTracker is not safe for concurrent use.
+type SequenceTracker struct {
+	states map[sequenceKey]*sequenceState
+}
+
+// NewSequenceTracker returns an empty SequenceTracker.
+func NewSequenceTracker() *SequenceTracker {
+	return &SequenceTracker{states: make(map[sequenceKey]*sequenceState)}
+}
+
+// Track records the message of type msgType with sequenceId seq from source.
+// It returns the status of the message and the number of messages lost
+// between the previous and this one.
+//
+// A message arriving late after being counted lost is no longer counted
+// lost.
+func (t *SequenceTracker) Track(source PortIdentity, msgType MsgType, seq uint16) (SequenceStatus, uint16) {
+	key := sequenceKey{source, msgType}
+	s, ok := t.states[key]
+	if !ok {
+		t.states[key] = &sequenceState{last: seq, seen: 1, counters: SequenceCounters{Received: 1}}
+		return SequenceFirst, 0
+	}
+
+	s.counters.Received++
+
+	d := int16(seq - s.last)
+	switch {
+	case d > 0:
+		s.seen = s.seen<<uint(d) | 1
+		s.last = seq
+
+		lost := uint16(d - 1)
+		if lost == 0 {
+			return SequenceInOrder, 0
+		}
+		s.counters.Lost += uint64(lost)
+
+		return SequenceGap, lost
+	case d == 0:
+		s.counters.Duplicated++
+		return SequenceDuplicate, 0
+	}
+
+	age := uint(-int(d))
+	if age < sequenceWindow {
+		if s.seen&(1<<age) != 0 {
+			s.counters.Duplicated++
+			return SequenceDuplicate, 0
+		}
+
+		s.seen |= 1 << age
+		if s.counters.Lost > 0 {
+			s.counters.Lost--
+		}
+	}
+	s.counters.OutOfOrder++
+
+	return SequenceOutOfOrder, 0
+}
+
+// Counters returns the counters of messages of type msgType from source.
+func (t *SequenceTracker) Counters(source PortIdentity, msgType MsgType) SequenceCounters {
+	if s, ok := t.states[sequenceKey{source, msgType}]; ok {
+		return s.counters
+	}
+
+	return SequenceCounters{}
+}
+
+// Reset forgets the messages of type msgType from source, for example after
+// the source restarted and its sequenceIds are no longer related.
+func (t *SequenceTracker) Reset(source PortIdentity, msgType MsgType) {
+	delete(t.states, sequenceKey{source, msgType})
+}
@@ -0,0 +1,109 @@
+package ptp
+
+import "testing"
+
+func TestSequenceTracker(t *testing.T) {
+	type step struct {
+		seq    uint16
+		status SequenceStatus
+		lost   uint16
+	}
+
+	var tests = []struct {
+		desc     string
+		steps    []step
+		counters SequenceCounters
+	}{
+		{
+			desc: "In order",
+			steps: []step{
+				{seq: 10, status: SequenceFirst},
+				{seq: 11, status: SequenceInOrder},
+				{seq: 12, status: SequenceInOrder},
+			},
+			counters: SequenceCounters{Received: 3},
+		},
+		{
+			desc: "Wraparound",
+			steps: []step{
+				{seq: 0xfffe, status: SequenceFirst},
+				{seq: 0xffff, status: SequenceInOrder},
+				{seq: 0, status: SequenceInOrder},
+				{seq: 2, status: SequenceGap, lost: 1},
+			},
+			counters: SequenceCounters{Received: 4, Lost: 1},
+		},
+		{
+			desc: "Gap",
+			steps: []step{
+				{seq: 1, status: SequenceFirst},
+				{seq: 5, status: SequenceGap, lost: 3},
+				{seq: 6, status: SequenceInOrder},
+			},
+			counters: SequenceCounters{Received: 3, Lost: 3},
+		},
+		{
+			desc: "Duplicate",
+			steps: []step{
+				{seq: 1, status: SequenceFirst},
+				{seq: 2, status: SequenceInOrder},
+				{seq: 2, status: SequenceDuplicate},
+				{seq: 3, status: SequenceInOrder},
+				{seq: 1, status: SequenceDuplicate},
+			},
+			counters: SequenceCounters{Received: 5, Duplicated: 2},
+		},
+		{
+			desc: "Reordered",
+			steps: []step{
+				{seq: 1, status: SequenceFirst},
+				{seq: 3, status: SequenceGap, lost: 1},
+				{seq: 2, status: SequenceOutOfOrder},
+				{seq: 2, status: SequenceDuplicate},
+				{seq: 4, status: SequenceInOrder},
+			},
+			counters: SequenceCounters{Received: 5, Duplicated: 1, OutOfOrder: 1},
+		},
+		{
+			desc: "Late beyond the window",
+			steps: []step{
+				{seq: 0xfff0, status: SequenceFirst},
+				{seq: 100, status: SequenceGap, lost: 115},
+				{seq: 0xfff0, status: SequenceOutOfOrder},
+			},
+			counters: SequenceCounters{Received: 3, Lost: 115, OutOfOrder: 1},
+		},
+	}
+
+	source := PortIdentity{ClockIdentity: 0x000af7fffe42a753, PortNumber: 1}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tr := NewSequenceTracker()
+
+			// Messages of other types and sources are tracked separately
+			tr.Track(source, FollowUpMsgType, 1000)
+			tr.Track(PortIdentity{ClockIdentity: 1}, SyncMsgType, 1000)
+
+			for i, s := range tt.steps {
+				status, lost := tr.Track(source, SyncMsgType, s.seq)
+				if want, got := s.status, status; want != got {
+					t.Fatalf("step %d: unexpected status: %v != %v", i, want, got)
+				}
+
+				if want, got := s.lost, lost; want != got {
+					t.Fatalf("step %d: unexpected lost messages: %v != %v", i, want, got)
+				}
+			}
+
+			if want, got := tt.counters, tr.Counters(source, SyncMsgType); want != got {
+				t.Fatalf("unexpected counters: %+v != %+v", want, got)
+			}
+
+			tr.Reset(source, SyncMsgType)
+			if want, got := (SequenceCounters{}), tr.Counters(source, SyncMsgType); want != got {
+				t.Fatalf("unexpected counters after reset: %+v != %+v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package ptp
+
+import "time"
+
+// ServoState is the state of a servo after a sample.
+type ServoState uint8
+
+const (
+	// ServoUnlocked servos need more samples before adjusting the clock
+	ServoUnlocked ServoState = iota
+	// ServoJump servos require the clock to be stepped by -offset before
+	// the frequency adjustment is applied
+	ServoJump
+	// ServoLocked servos adjust the frequency of the clock
+	ServoLocked
+	// ServoLockedStable servos are locked with offsets within the stable
+	// threshold
+	ServoLockedStable
+)
+
+var servoStateNames = map[ServoState]string{
+	ServoUnlocked:     "UNLOCKED",
+	ServoJump:         "JUMP",
+	ServoLocked:       "LOCKED",
+	ServoLockedStable: "LOCKED_STABLE",
+}
+
+// String returns the name of s.
+func (s ServoState) String() string {
+	name, ok := servoStateNames[s]
+	return enumString(name, ok, uint64(s))
+}
+
+// Servo disciplines a clock from its offsets from the master.
+type Servo interface {
+	// Sample feeds the offset of the clock from the master, measured at
+	// local time ts, and returns the frequency adjustment of the clock in
+	// ppb, positive to speed it up, and the state of the servo. The
+	// adjustment replaces the previous one and is not applied in the
+	// ServoUnlocked state.
+	Sample(offset time.Duration, ts time.Time) (float64, ServoState)
+	// Reset discards the samples, the next one starts over in the
+	// ServoUnlocked state. The frequency estimate is kept.
+	Reset()
+}
+
+// Defaults of the servos, those of linuxptp.
+const (
+	// DefaultFirstStepThreshold is the largest offset of the first
+	// adjustment corrected by the frequency rather than by a step
+	DefaultFirstStepThreshold = 20 * time.Microsecond
+	// DefaultServoMaxFrequency is the largest frequency adjustment in ppb
+	DefaultServoMaxFrequency = 900000000
+	// DefaultStableSamples is the number of consecutive offsets within
+	// StableThreshold of a stable servo
+	DefaultStableSamples = 10
+)
+
+// ServoConfig holds the settings common to all servos.
+type ServoConfig struct {
+	// StepThreshold is the largest offset corrected by the frequency, larger
+	// offsets unlock the servo. 0 disables steps after the first
+	// adjustment.
+	StepThreshold time.Duration
+	// FirstStepThreshold is the largest offset of the first adjustment
+	// corrected by the frequency, larger offsets step the clock. 0 disables
+	// the first step.
+	FirstStepThreshold time.Duration
+	// MaxFrequency bounds the frequency adjustment, in ppb
+	MaxFrequency float64
+	// StableThreshold is the largest offset of a stable servo, 0 disables
+	// the ServoLockedStable state
+	StableThreshold time.Duration
+	StableSamples   int
+}
+
+// DefaultServoConfig returns the default ServoConfig.
+func DefaultServoConfig() ServoConfig {
+	return ServoConfig{
+		FirstStepThreshold: DefaultFirstStepThreshold,
+		MaxFrequency:       DefaultServoMaxFrequency,
+		StableSamples:      DefaultStableSamples,
+	}
+}
+
+// servoLock tracks the first adjustment and the stability of a servo, as the
+// servo layer of linuxptp.
+type servoLock struct {
+	updated bool
+	stable  int
+}
+
+// step reports whether the adjustment of offset steps the clock.
+func (l *servoLock) step(c *ServoConfig, offset time.Duration) bool {
+	if !l.updated && c.FirstStepThreshold > 0 && abs(offset) > c.FirstStepThreshold {
+		return true
+	}
+	return c.unlock(offset)
+}
+
+// unlock reports whether offset exceeds the step threshold of a locked
+// servo.
+func (c *ServoConfig) unlock(offset time.Duration) bool {
+	return c.StepThreshold > 0 && abs(offset) > c.StepThreshold
+}
+
+// update returns the state of a servo after a sample of offset, which is
+// ServoLockedStable once StableSamples consecutive offsets of a locked
+// servo are within StableThreshold.
+func (l *servoLock) update(c *ServoConfig, state ServoState, offset time.Duration) ServoState {
+	switch state {
+	case ServoUnlocked:
+		l.stable = 0
+		return state
+	case ServoJump:
+		l.stable = 0
+		l.updated = true
+		return state
+	}
+
+	l.updated = true
+	if c.StableThreshold <= 0 || abs(offset) >= c.StableThreshold {
+		l.stable = 0
+		return ServoLocked
+	}
+
+	if l.stable < c.StableSamples {
+		l.stable++
+	}
+	if l.stable < c.StableSamples {
+		return ServoLocked
+	}
+	return ServoLockedStable
+}
+
+// clamp bounds the frequency adjustment ppb to MaxFrequency and reports
+// whether it was within the bounds.
+func (c *ServoConfig) clamp(ppb float64) (float64, bool) {
+	max := c.MaxFrequency
+	if max <= 0 {
+		return ppb, true
+	}
+	if ppb > max {
+		return max, false
+	}
+	if ppb < -max {
+		return -max, false
+	}
+	return ppb, true
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// ServoType selects a servo implementation.
+type ServoType uint8
+
+const (
+	ServoPI ServoType = iota
+	ServoLinReg
+	ServoKalman
+)
+
+// servoTypeNames are the names of the servos as in the clock_servo option
+// of linuxptp
+var servoTypeNames = map[ServoType]string{
+	ServoPI:     "pi",
+	ServoLinReg: "linreg",
+	ServoKalman: "kalman",
+}
+
+// String returns the name of t.
+func (t ServoType) String() string {
+	name, ok := servoTypeNames[t]
+	return enumString(name, ok, uint64(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ServoType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *ServoType) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 8, func(s string) (uint64, bool) {
+		for k, name := range servoTypeNames {
+			if name == s {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*t = ServoType(v)
+	return err
+}
+
+// NewServo returns a servo of type t with settings c, of a clock adjusted by
+// freq ppb. The other settings of the servo are the defaults.
+func NewServo(t ServoType, c ServoConfig, freq float64) (Servo, error) {
+	switch t {
+	case ServoPI:
+		s := NewPIServo(freq)
+		s.ServoConfig = c
+		return s, nil
+	case ServoLinReg:
+		s := NewLinRegServo(freq)
+		s.ServoConfig = c
+		return s, nil
+	case ServoKalman:
+		s := NewKalmanServo(freq)
+		s.ServoConfig = c
+		return s, nil
+	}
+
+	return nil, ErrUnknownServo
+}
@@ -0,0 +1,285 @@
+package ptp
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// runServo disciplines c with s from n offsets measured every interval and
+// returns the states of s and the offsets of c after each sample.
+func runServo(t *testing.T, c *SimClock, s Servo, n int, interval time.Duration) ([]ServoState, []time.Duration) {
+	var states []ServoState
+	var offsets []time.Duration
+	for i := 0; i < n; i++ {
+		now, err := c.Now()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		state, err := Discipline(c, s, now.Sub(c.Reference()), now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		states = append(states, state)
+
+		c.Advance(interval)
+		offsets = append(offsets, c.Offset())
+	}
+
+	return states, offsets
+}
+
+func TestServoState(t *testing.T) {
+	if want, got := "LOCKED_STABLE", ServoLockedStable.String(); want != got {
+		t.Fatalf("unexpected name: %v != %v", want, got)
+	}
+}
+
+func TestServoType(t *testing.T) {
+	for _, want := range []ServoType{ServoPI, ServoLinReg, ServoKalman} {
+		b, err := want.MarshalText()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got ServoType
+		if err := got.UnmarshalText(b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want != got {
+			t.Fatalf("unexpected servo type: %v != %v", want, got)
+		}
+
+		if _, err := NewServo(got, DefaultServoConfig(), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var typ ServoType
+	if err := typ.UnmarshalText([]byte("ntpshm")); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := NewServo(ServoType(9), DefaultServoConfig(), 0); err != ErrUnknownServo {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// rms returns the root mean square of v in ns.
+func rms(v []time.Duration) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum / float64(len(v)))
+}
+
+func TestServos(t *testing.T) {
+	config := DefaultServoConfig()
+	config.StableThreshold = 100 * time.Nanosecond
+
+	for _, typ := range []ServoType{ServoPI, ServoLinReg, ServoKalman} {
+		t.Run(typ.String(), func(t *testing.T) {
+			s, err := NewServo(typ, config, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			c := NewSimClock(time.Unix(1000, 0), time.Millisecond, 50000, 1)
+			states, _ := runServo(t, c, s, 60, time.Second)
+
+			want := []ServoState{ServoUnlocked, ServoJump, ServoLocked}
+			for i, want := range want {
+				if got := states[i]; want != got {
+					t.Fatalf("unexpected state %d: %v != %v", i, want, got)
+				}
+			}
+
+			if want, got := ServoLockedStable, states[59]; want != got {
+				t.Fatalf("unexpected state: %v != %v", want, got)
+			}
+
+			if got := abs(c.Offset()); got > time.Nanosecond {
+				t.Fatalf("unexpected offset: %v", got)
+			}
+
+			if want, got := -50000.0, c.Frequency(); math.Abs(want-got) > 1 {
+				t.Fatalf("unexpected frequency: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestServosPacketDelayVariation(t *testing.T) {
+	// Offsets measured with a packet delay variation of 200ns
+	const pdv = 200
+
+	var tests = []struct {
+		typ    ServoType
+		config func(s Servo)
+		// largest RMS of the offsets of the clock, in ns
+		maxRMS float64
+	}{
+		{typ: ServoPI, maxRMS: 300},
+		{typ: ServoLinReg, maxRMS: 150},
+		{
+			typ:    ServoKalman,
+			config: func(s Servo) { s.(*KalmanServo).MeasurementNoise = pdv * pdv },
+			maxRMS: 60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			s, err := NewServo(tt.typ, DefaultServoConfig(), 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.config != nil {
+				tt.config(s)
+			}
+
+			// Each servo is fed the same noise
+			c := NewSimClock(time.Unix(1000, 0), 0, 10000, 1)
+			c.Noise = pdv
+			_, offsets := runServo(t, c, s, 600, time.Second)
+
+			got := rms(offsets[300:])
+			t.Logf("%v: %.1fns", tt.typ, got)
+			if got > tt.maxRMS {
+				t.Fatalf("unexpected RMS offset: %.1fns > %vns", got, tt.maxRMS)
+			}
+		})
+	}
+}
+
+func TestPIServo(t *testing.T) {
+	var tests = []struct {
+		desc   string
+		offset time.Duration
+		drift  float64
+		config func(s *PIServo)
+		// states expected first and last
+		first []ServoState
+		last  ServoState
+		// offset expected after 60 samples
+		maxOffset time.Duration
+	}{
+		{
+			desc:      "First step",
+			offset:    time.Millisecond,
+			drift:     50000,
+			first:     []ServoState{ServoUnlocked, ServoJump, ServoLocked},
+			last:      ServoLockedStable,
+			maxOffset: time.Nanosecond,
+		},
+		{
+			desc:      "Offset within the first step threshold",
+			offset:    10 * time.Microsecond,
+			drift:     -20000,
+			first:     []ServoState{ServoUnlocked, ServoLocked, ServoLocked},
+			last:      ServoLockedStable,
+			maxOffset: time.Nanosecond,
+		},
+		{
+			desc:      "First step disabled",
+			offset:    time.Millisecond,
+			drift:     50000,
+			config:    func(s *PIServo) { s.FirstStepThreshold = 0 },
+			first:     []ServoState{ServoUnlocked, ServoLocked, ServoLocked},
+			last:      ServoLockedStable,
+			maxOffset: time.Nanosecond,
+		},
+		{
+			desc:      "Stable state disabled",
+			offset:    time.Microsecond,
+			drift:     100,
+			config:    func(s *PIServo) { s.StableThreshold = 0 },
+			first:     []ServoState{ServoUnlocked, ServoLocked},
+			last:      ServoLocked,
+			maxOffset: time.Nanosecond,
+		},
+		{
+			desc:   "Drift beyond max frequency",
+			drift:  100000,
+			config: func(s *PIServo) { s.MaxFrequency, s.FirstStepThreshold = 50000, 0 },
+			first:  []ServoState{ServoUnlocked, ServoLocked},
+			last:   ServoLocked,
+			// The clock gains 50us per second
+			maxOffset: time.Hour,
+		},
+		{
+			desc:      "Step threshold",
+			offset:    time.Millisecond,
+			drift:     50000,
+			config:    func(s *PIServo) { s.StepThreshold = 100 * time.Microsecond },
+			first:     []ServoState{ServoUnlocked, ServoJump, ServoLocked},
+			last:      ServoLockedStable,
+			maxOffset: time.Nanosecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := NewPIServo(0)
+			s.StableThreshold = 100 * time.Nanosecond
+			if tt.config != nil {
+				tt.config(s)
+			}
+
+			c := NewSimClock(time.Unix(1000, 0), tt.offset, tt.drift, 1)
+			states, _ := runServo(t, c, s, 60, time.Second)
+
+			for i, want := range tt.first {
+				if got := states[i]; want != got {
+					t.Fatalf("unexpected state %d: %v != %v", i, want, got)
+				}
+			}
+
+			if want, got := tt.last, states[len(states)-1]; want != got {
+				t.Fatalf("unexpected last state: %v != %v", want, got)
+			}
+
+			if want, got := tt.maxOffset, abs(c.Offset()); got > want {
+				t.Fatalf("unexpected offset: %v > %v", got, want)
+			}
+
+			if want, got := math.Min(tt.drift, s.MaxFrequency), -c.Frequency(); math.Abs(want-got) > 1 {
+				t.Fatalf("unexpected frequency: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestPIServoUnlock(t *testing.T) {
+	s := NewPIServo(0)
+	s.StepThreshold = 100 * time.Microsecond
+	s.StableThreshold = 100 * time.Nanosecond
+
+	c := NewSimClock(time.Unix(1000, 0), time.Millisecond, 50000, 1)
+	states, _ := runServo(t, c, s, 60, time.Second)
+	if want, got := ServoLockedStable, states[59]; want != got {
+		t.Fatalf("unexpected state: %v != %v", want, got)
+	}
+
+	// The clock is stepped by the master
+	c.Step(time.Millisecond)
+	states, _ = runServo(t, c, s, 60, time.Second)
+
+	want := []ServoState{ServoUnlocked, ServoUnlocked, ServoJump, ServoLocked}
+	for i, want := range want {
+		if got := states[i]; want != got {
+			t.Fatalf("unexpected state %d: %v != %v", i, want, got)
+		}
+	}
+
+	if want, got := ServoLockedStable, states[59]; want != got {
+		t.Fatalf("unexpected state: %v != %v", want, got)
+	}
+
+	// The frequency estimate survives the unlock
+	if want, got := -50000.0, c.Frequency(); math.Abs(want-got) > 1 {
+		t.Fatalf("unexpected frequency: %v != %v", want, got)
+	}
+}
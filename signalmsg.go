@@ -16,27 +16,44 @@ type SignalingMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *SignalingMsg) MarshalBinary() ([]byte, error) {
 
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a SignalingMsg in binary form.
+func (t *SignalingMsg) MarshalLen() int {
+	return HeaderLen + SignalingPayloadLen + t.IntervalRequestTlv.MarshalLen()
+}
+
+// AppendBinary appends the binary form of a SignalingMsg to b.
+func (t *SignalingMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a SignalingMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *SignalingMsg) MarshalTo(b []byte) (int, error) {
 	if t.Header.MessageType != SignalingMsgType {
-		return nil, ErrInvalidMsgType
+		return 0, ErrInvalidMsgType
 	}
 
 	if t.Header.MessageLength == 0 {
 		t.Header.MessageLength = HeaderLen + SignalingPayloadLen + IntervalRequestTlvLen
 	}
 
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
 	}
 
-	tlvSlice, err := t.IntervalRequestTlv.MarshalBinary()
-	if err != nil {
-		return nil, err
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
 	}
-
-	b := make([]byte, HeaderLen+SignalingPayloadLen+IntervalRequestTlvLen+4)
-
-	copy(b[:HeaderLen], headerSlice)
 	offset := HeaderLen
 
 	binary.BigEndian.PutUint64(b[offset:offset+ClockIdentityLen], t.ClockIdentity)
@@ -45,26 +62,36 @@ func (t *SignalingMsg) MarshalBinary() ([]byte, error) {
 	binary.BigEndian.PutUint16(b[offset:offset+SourcePortNumberLen], t.PortNumber)
 	offset += SourcePortNumberLen
 
-	copy(b[offset:offset+IntervalRequestTlvLen+4], tlvSlice)
+	if _, err := t.IntervalRequestTlv.MarshalTo(b[offset:n]); err != nil {
+		return 0, err
+	}
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a SignalingMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid SignalingMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are decoded as TLVs, those too short for a TLV
+// are ignored.
 func (t *SignalingMsg) UnmarshalBinary(b []byte) error {
-	if len(b) != HeaderLen+SignalingPayloadLen+IntervalRequestTlvLen+4 {
-		return io.ErrUnexpectedEOF
-	}
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
+
+// unmarshal decodes a SignalingMsg accordingly with the options.
+func (t *SignalingMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, SignalingMsgType, HeaderLen+SignalingPayloadLen+IntervalRequestTlvLen+4)
 	if err != nil {
 		return err
 	}
 
+	if err = t.Header.unmarshal(b, o); err != nil {
+		return err
+	}
+
 	if t.Header.MessageType != SignalingMsgType {
-		return ErrInvalidMsgType
+		return decodeError(SignalingMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
 	}
 
 	offset := HeaderLen
@@ -74,9 +101,22 @@ func (t *SignalingMsg) UnmarshalBinary(b []byte) error {
 	t.PortNumber = binary.BigEndian.Uint16(b[offset : offset+SourcePortNumberLen])
 	offset += SourcePortNumberLen
 
-	if err = t.IntervalRequestTlv.UnmarshalBinary(b[offset:]); err != nil {
+	tlv := b[offset : offset+IntervalRequestTlvLen+4]
+	if err = t.IntervalRequestTlv.UnmarshalBinary(tlv); err != nil {
+		return decodeError(SignalingMsgType, "tlv", offset, TlvType(binary.BigEndian.Uint16(tlv[0:2])), err)
+	}
+
+	// Flags other than computeNeighborRateRatio and computeNeighborPropDelay,
+	// and 2 bytes following them are reserved
+	if err = o.reserved(&t.Header, []byte{tlv[13] &^ 0x6, tlv[14], tlv[15]}, offset+13); err != nil {
 		return err
 	}
+	offset += IntervalRequestTlvLen + 4
+
+	return o.walkTlvs(b[offset:], SignalingMsgType, offset, func(TlvType, []byte) error { return nil })
+}
 
-	return nil
+// Validate checks that a SignalingMsg conforms to IEEE 1588.
+func (t *SignalingMsg) Validate() error {
+	return t.Header.validate(SignalingMsgType, t.MarshalLen(), true)
 }
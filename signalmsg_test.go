@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -129,13 +130,42 @@ func TestUnmarshalSignaling(t *testing.T) {
 				0x2, 0x0, 0x0,
 			}),
 		},
+		{
+			desc: "Padded frame",
+			m: &SignalingMsg{
+				Header: Header{
+					MessageType:      SignalingMsgType,
+					MessageLength:    HeaderLen + SignalingPayloadLen + IntervalRequestTlvLen + 4,
+					VersionPTP:       Version2,
+					ClockIdentity:    0x001d7ffffe80024a,
+					PortNumber:       1,
+					SequenceID:       27278,
+					LogMessagePeriod: 127,
+				},
+				ClockIdentity: 0x78baf9fffe0a435e,
+				PortNumber:    1,
+				IntervalRequestTlv: IntervalRequestTlv{
+					LinkDelayInterval:        1,
+					TimeSyncInterval:         2,
+					AnnounceInterval:         127,
+					ComputeNeighborRateRatio: true,
+				},
+			},
+			b: append([]byte{0x0c, 0x02, 0x0, 0x3c, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1d,
+				0x7f, 0xff, 0xfe, 0x80, 0x02, 0x4a, 0x00, 0x01, 0x6a, 0x8e, 0x05, 0x7f, 0x78, 0xba, 0xf9, 0xff,
+				0xfe, 0x0a, 0x43, 0x5e, 0x00, 0x01, 0x00, 0x03, 0x00, 0x0c, 0x0, 0x80, 0xc2, 0x0, 0x0, 0x2, 0x1, 0x2, 0x7f,
+				0x2, 0x0, 0x0},
+				// Padding
+				0x0, 0x0),
+		},
 		{
 			desc: "Invalid length",
 			b: append([]byte{0x0c, 0x02, 0x0, 0x3c, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
 				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1d,
 				0x7f, 0xff, 0xfe, 0x80, 0x02, 0x4a, 0x00, 0x01, 0x6a, 0x8e, 0x05, 0x7f, 0x78, 0xba, 0xf9, 0xff,
 				0xfe, 0x0a, 0x43, 0x5e, 0x00, 0x01, 0x00, 0x03, 0x00, 0x0c, 0x0, 0x80, 0xc2, 0x0, 0x0, 0x2, 0x1, 0x2, 0x7f,
-				0x2, 0x0, 0x0, 0x0}),
+				0x2, 0x0}),
 			err: io.ErrUnexpectedEOF,
 		},
 		{
@@ -154,7 +184,7 @@ func TestUnmarshalSignaling(t *testing.T) {
 			m := new(SignalingMsg)
 			err := m.UnmarshalBinary(tt.b)
 			if err != nil {
-				if want, got := tt.err, err; want != got {
+				if want, got := tt.err, err; !errors.Is(got, want) {
 					t.Fatalf("unexpected error: %v != %v", want, got)
 				}
 
@@ -0,0 +1,98 @@
+package ptp
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DefaultSimMaxAdjustment is the default MaxAdjustment of SimClock in ppb.
+const DefaultSimMaxAdjustment = 500000
+
+// SimClock is a simulated clock following a reference time advanced by
+// Advance. Its frequency error drifts by a random walk, and its timestamps
+// are noisy.
+//
+// A SimClock is not safe for concurrent use.
+type SimClock struct {
+	// Drift is the frequency error of the clock in ppb
+	Drift float64
+	// Wander is the standard deviation of the random walk of Drift, in ppb
+	// per square root of second
+	Wander float64
+	// Noise is the standard deviation of the timestamps of Now
+	Noise time.Duration
+	// MaxAdj is the largest frequency adjustment in ppb
+	MaxAdj float64
+
+	rand   *rand.Rand
+	ref    time.Time
+	offset float64
+	freq   float64
+}
+
+// NewSimClock returns a SimClock at offset from the reference time ref, with
+// frequency error drift ppb. The random walk and the noise are generated
+// from seed.
+func NewSimClock(ref time.Time, offset time.Duration, drift float64, seed int64) *SimClock {
+	return &SimClock{
+		Drift:  drift,
+		MaxAdj: DefaultSimMaxAdjustment,
+		rand:   rand.New(rand.NewSource(seed)),
+		ref:    ref,
+		offset: float64(offset),
+	}
+}
+
+// Advance advances the reference time by d.
+func (c *SimClock) Advance(d time.Duration) {
+	c.offset += (c.Drift + c.freq) * d.Seconds()
+	c.Drift += c.Wander * math.Sqrt(d.Seconds()) * c.rand.NormFloat64()
+	c.ref = c.ref.Add(d)
+}
+
+// Reference returns the reference time.
+func (c *SimClock) Reference() time.Time {
+	return c.ref
+}
+
+// Offset returns the offset of the clock from the reference time.
+func (c *SimClock) Offset() time.Duration {
+	return time.Duration(c.offset)
+}
+
+// Frequency returns the frequency adjustment of the clock in ppb.
+func (c *SimClock) Frequency() float64 {
+	return c.freq
+}
+
+// Now implements Clock.
+func (c *SimClock) Now() (time.Time, error) {
+	noise := float64(c.Noise) * c.rand.NormFloat64()
+	return c.ref.Add(time.Duration(c.offset + noise)), nil
+}
+
+// TimerNow implements TimerClock, the timers run on the reference time.
+func (c *SimClock) TimerNow() time.Time {
+	return c.ref
+}
+
+// AdjustFrequency implements Clock.
+func (c *SimClock) AdjustFrequency(ppb float64) error {
+	if math.Abs(ppb) > c.MaxAdj {
+		return ErrOutOfRange
+	}
+	c.freq = ppb
+	return nil
+}
+
+// Step implements Clock.
+func (c *SimClock) Step(d time.Duration) error {
+	c.offset += float64(d)
+	return nil
+}
+
+// MaxAdjustment implements Clock.
+func (c *SimClock) MaxAdjustment() float64 {
+	return c.MaxAdj
+}
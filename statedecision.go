@@ -0,0 +1,157 @@
+package ptp
+
+// StateDecisionCode identifies the branch of the state decision algorithm
+// taken for a port. It tells which data sets are updated with the decision,
+// see IEEE 1588-2008 9.3.5.
+type StateDecisionCode uint8
+
+const (
+	// DecisionNone keeps a LISTENING port without foreign masters listening
+	DecisionNone StateDecisionCode = iota
+	// DecisionM1 makes the port of a clock of class 1 through 127 master
+	DecisionM1
+	// DecisionM2 makes the port master, the local clock is the best
+	DecisionM2
+	// DecisionM3 makes the port master of a clock synchronized through
+	// another port
+	DecisionM3
+	// DecisionP1 makes the port of a clock of class 1 through 127 passive
+	DecisionP1
+	// DecisionP2 makes the port passive, it hears the best master through
+	// another port
+	DecisionP2
+	// DecisionS1 makes the port slave of the best master
+	DecisionS1
+)
+
+var stateDecisionCodeNames = map[StateDecisionCode]string{
+	DecisionNone: "none",
+	DecisionM1:   "M1",
+	DecisionM2:   "M2",
+	DecisionM3:   "M3",
+	DecisionP1:   "P1",
+	DecisionP2:   "P2",
+	DecisionS1:   "S1",
+}
+
+// String returns the name of c.
+func (c StateDecisionCode) String() string {
+	name, ok := stateDecisionCodeNames[c]
+	return enumString(name, ok, uint64(c))
+}
+
+// StateDecision is the state recommended for a port.
+type StateDecision struct {
+	Code  StateDecisionCode
+	State PortState
+}
+
+// ComparisonDataset returns the data set D0 of the local clock, compared
+// with the data sets of foreign masters.
+func (d DefaultDataSetTlv) ComparisonDataset() ComparisonDataset {
+	id := PortIdentity{ClockIdentity: d.ClockIdentity}
+
+	return ComparisonDataset{
+		GMPriority1:    d.Priority1,
+		GMIdentity:     d.ClockIdentity,
+		GMClockQuality: d.ClockQuality,
+		GMPriority2:    d.Priority2,
+		Sender:         id,
+		Receiver:       id,
+	}
+}
+
+// BestDataset returns the index of the best of sets, skipping nil data sets,
+// or -1 if there is none. Data sets that cannot be compared are not
+// selected.
+func BestDataset(sets []*ComparisonDataset) int {
+	best := -1
+	for i, d := range sets {
+		if d == nil {
+			continue
+		}
+
+		if best < 0 {
+			best = i
+			continue
+		}
+
+		if r, err := d.Compare(*sets[best]); err == nil && r.Better() {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// DecideState runs the state decision algorithm of IEEE 1588-2008 figure 26
+// for a port in state, where erbest is the best foreign master data set
+// received on the port and ebest the best of all ports, both nil if there
+// are none. ebestPort tells whether ebest was received on the port.
+//
+// Ports of a slave-only clock are recommended LISTENING instead of MASTER.
+func DecideState(defaultDS DefaultDataSetTlv, state PortState, erbest, ebest *ComparisonDataset, ebestPort bool) StateDecision {
+	if erbest == nil && state == Listening {
+		return StateDecision{DecisionNone, Listening}
+	}
+
+	d0 := defaultDS.ComparisonDataset()
+	class := defaultDS.ClockClass
+
+	var d StateDecision
+	switch {
+	case class >= 1 && class <= 127:
+		if betterThan(d0, erbest) {
+			d = StateDecision{DecisionM1, Master}
+		} else {
+			d = StateDecision{DecisionP1, Passive}
+		}
+	case betterThan(d0, ebest):
+		d = StateDecision{DecisionM2, Master}
+	case ebestPort:
+		d = StateDecision{DecisionS1, Slave}
+	default:
+		d = StateDecision{DecisionM3, Master}
+		if erbest != nil {
+			if r, err := ebest.Compare(*erbest); err == nil && r == DatasetBetterByTopology {
+				d = StateDecision{DecisionP2, Passive}
+			}
+		}
+	}
+
+	if defaultDS.SO && d.State == Master {
+		d.State = Listening
+	}
+
+	return d
+}
+
+// DecideStates runs the state decision algorithm for every port, where
+// states and erbest hold the state and the best foreign master data set of
+// each port.
+func DecideStates(defaultDS DefaultDataSetTlv, states []PortState, erbest []*ComparisonDataset) []StateDecision {
+	best := BestDataset(erbest)
+
+	var ebest *ComparisonDataset
+	if best >= 0 {
+		ebest = erbest[best]
+	}
+
+	decisions := make([]StateDecision, len(states))
+	for i, state := range states {
+		decisions[i] = DecideState(defaultDS, state, erbest[i], ebest, i == best)
+	}
+
+	return decisions
+}
+
+// betterThan reports whether a is better than b, by topology or not. Any data
+// set is better than none.
+func betterThan(a ComparisonDataset, b *ComparisonDataset) bool {
+	if b == nil {
+		return true
+	}
+
+	r, err := a.Compare(*b)
+	return err == nil && r.Better()
+}
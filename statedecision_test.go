@@ -0,0 +1,153 @@
+package ptp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecideStates(t *testing.T) {
+	const local uint64 = 0x000af7fffe000010
+
+	defaultDS := DefaultDataSetTlv{
+		NumberPorts:   2,
+		Priority1:     128,
+		ClockQuality:  ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracyNotSupported, ClockVariance: 0xffff},
+		Priority2:     128,
+		ClockIdentity: local,
+	}
+
+	// Data set of an Announce message of grandmaster gm from sender received
+	// on port number n of the local clock
+	announce := func(gm uint64, class ClockClassType, steps uint16, sender uint64, n uint16) *ComparisonDataset {
+		return &ComparisonDataset{
+			GMPriority1:    128,
+			GMIdentity:     gm,
+			GMClockQuality: ClockQuality{ClockClass: class, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 0x4e5d},
+			GMPriority2:    128,
+			StepsRemoved:   steps,
+			Sender:         PortIdentity{ClockIdentity: sender, PortNumber: 1},
+			Receiver:       PortIdentity{ClockIdentity: local, PortNumber: n},
+		}
+	}
+
+	primary := func(d *DefaultDataSetTlv) { d.ClockClass = PrimarySyncRefClass }
+	slaveOnly := func(d *DefaultDataSetTlv) { d.SO = true }
+
+	var tests = []struct {
+		desc      string
+		defaultDS func(d *DefaultDataSetTlv)
+		states    []PortState
+		erbest    []*ComparisonDataset
+		want      []StateDecision
+	}{
+		{
+			desc:   "Listening without foreign masters",
+			states: []PortState{Listening, Listening},
+			erbest: []*ComparisonDataset{nil, nil},
+			want:   []StateDecision{{DecisionNone, Listening}, {DecisionNone, Listening}},
+		},
+		{
+			desc:   "Master without foreign masters",
+			states: []PortState{Master, Listening},
+			erbest: []*ComparisonDataset{nil, nil},
+			want:   []StateDecision{{DecisionM2, Master}, {DecisionNone, Listening}},
+		},
+		{
+			desc:   "Local clock is better",
+			states: []PortState{Listening, Master},
+			erbest: []*ComparisonDataset{announce(0x20, 255, 0, 0x20, 1), nil},
+			want:   []StateDecision{{DecisionM2, Master}, {DecisionM2, Master}},
+		},
+		{
+			desc:   "Slave of the best master",
+			states: []PortState{Listening, Listening},
+			erbest: []*ComparisonDataset{announce(0x20, 6, 0, 0x20, 1), announce(0x30, 7, 0, 0x30, 2)},
+			want:   []StateDecision{{DecisionS1, Slave}, {DecisionM3, Master}},
+		},
+		{
+			desc:   "Master of a clock synchronized through another port",
+			states: []PortState{Slave, Master},
+			erbest: []*ComparisonDataset{announce(0x20, 6, 0, 0x20, 1), nil},
+			want:   []StateDecision{{DecisionS1, Slave}, {DecisionM3, Master}},
+		},
+		{
+			desc:   "Passive hearing the best master through another port",
+			states: []PortState{Slave, Master},
+			erbest: []*ComparisonDataset{announce(0x20, 6, 1, 0x30, 1), announce(0x20, 6, 1, 0x40, 2)},
+			want:   []StateDecision{{DecisionS1, Slave}, {DecisionP2, Passive}},
+		},
+		{
+			desc:   "Master of a downstream clock of the same grandmaster",
+			states: []PortState{Slave, Master},
+			erbest: []*ComparisonDataset{announce(0x20, 6, 1, 0x30, 1), announce(0x20, 6, 3, 0x40, 2)},
+			want:   []StateDecision{{DecisionS1, Slave}, {DecisionM3, Master}},
+		},
+		{
+			desc:      "Primary clock better than foreign masters",
+			defaultDS: primary,
+			states:    []PortState{Listening, Listening},
+			erbest:    []*ComparisonDataset{announce(0x20, 7, 0, 0x20, 1), nil},
+			want:      []StateDecision{{DecisionM1, Master}, {DecisionNone, Listening}},
+		},
+		{
+			desc:      "Primary clock worse than a foreign master",
+			defaultDS: primary,
+			states:    []PortState{Master, Master},
+			erbest:    []*ComparisonDataset{announce(0x05, 6, 0, 0x05, 1), nil},
+			want:      []StateDecision{{DecisionP1, Passive}, {DecisionM1, Master}},
+		},
+		{
+			desc:      "Primary clock never slave",
+			defaultDS: primary,
+			states:    []PortState{Listening},
+			erbest:    []*ComparisonDataset{announce(0x05, 6, 0, 0x05, 1)},
+			want:      []StateDecision{{DecisionP1, Passive}},
+		},
+		{
+			desc:      "Slave-only clock",
+			defaultDS: slaveOnly,
+			states:    []PortState{Listening, Listening},
+			erbest:    []*ComparisonDataset{announce(0x20, 6, 0, 0x20, 1), announce(0x30, 7, 0, 0x30, 2)},
+			want:      []StateDecision{{DecisionS1, Slave}, {DecisionM3, Listening}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ds := defaultDS
+			if tt.defaultDS != nil {
+				tt.defaultDS(&ds)
+			}
+
+			got := DecideStates(ds, tt.states, tt.erbest)
+			if want := tt.want; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected decisions:\n- want: %v\n-  got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestBestDataset(t *testing.T) {
+	a := &ComparisonDataset{GMPriority1: 128, GMIdentity: 1}
+	b := &ComparisonDataset{GMPriority1: 127, GMIdentity: 2}
+
+	var tests = []struct {
+		desc string
+		sets []*ComparisonDataset
+		want int
+	}{
+		{desc: "Empty", sets: nil, want: -1},
+		{desc: "Only nil", sets: []*ComparisonDataset{nil, nil}, want: -1},
+		{desc: "Single", sets: []*ComparisonDataset{nil, a}, want: 1},
+		{desc: "Best last", sets: []*ComparisonDataset{a, nil, b}, want: 2},
+		{desc: "Best first", sets: []*ComparisonDataset{b, a}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.want, BestDataset(tt.sets); want != got {
+				t.Fatalf("unexpected index: %v != %v", want, got)
+			}
+		})
+	}
+}
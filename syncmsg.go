@@ -14,8 +14,30 @@ type SyncMsg struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (t *SyncMsg) MarshalBinary() ([]byte, error) {
 
+	b := make([]byte, t.MarshalLen())
+
+	if _, err := t.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a SyncMsg in binary form.
+func (t *SyncMsg) MarshalLen() int {
+	return HeaderLen + SyncPayloadLen
+}
+
+// AppendBinary appends the binary form of a SyncMsg to b.
+func (t *SyncMsg) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, t.MarshalLen(), t.MarshalTo)
+}
+
+// MarshalTo marshals a SyncMsg into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (t *SyncMsg) MarshalTo(b []byte) (int, error) {
 	if t.Header.MessageType != SyncMsgType {
-		return nil, ErrInvalidMsgType
+		return 0, ErrInvalidMsgType
 	}
 
 	if t.Header.MessageLength == 0 {
@@ -23,45 +45,56 @@ func (t *SyncMsg) MarshalBinary() ([]byte, error) {
 	}
 
 	if t.Header.MessageLength != HeaderLen+SyncPayloadLen {
-		return nil, io.ErrUnexpectedEOF
+		return 0, io.ErrUnexpectedEOF
 	}
 
-	b := make([]byte, HeaderLen+SyncPayloadLen)
-
-	headerSlice, err := t.Header.MarshalBinary()
-	if err != nil {
-		return nil, err
+	n := t.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
 	}
 
-	copy(b[:HeaderLen], headerSlice)
+	if _, err := t.Header.MarshalTo(b); err != nil {
+		return 0, err
+	}
 
 	// Origin timestamp
-	time2OriginTimestamp(t.OriginTimestamp, b[HeaderLen:])
+	time2OriginTimestamp(t.OriginTimestamp, b[HeaderLen:n])
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a SyncMsg.
 //
 // If the byte slice does not contain enough data to unmarshal a valid SyncMsg,
 // io.ErrUnexpectedEOF is returned.
+// Bytes following the message are ignored.
 func (t *SyncMsg) UnmarshalBinary(b []byte) error {
-	if len(b) < HeaderLen+SyncPayloadLen {
-		return io.ErrUnexpectedEOF
-	}
+	return t.unmarshal(b, &paddedDecodeOptions)
+}
 
-	err := t.Header.UnmarshalBinary(b[:HeaderLen])
+// unmarshal decodes a SyncMsg accordingly with the options.
+func (t *SyncMsg) unmarshal(b []byte, o *DecodeOptions) error {
+	b, err := o.message(b, SyncMsgType, HeaderLen+SyncPayloadLen)
 	if err != nil {
 		return err
 	}
 
+	if err = t.Header.unmarshal(b, o); err != nil {
+		return err
+	}
+
 	if t.Header.MessageType != SyncMsgType {
-		return ErrInvalidMsgType
+		return decodeError(SyncMsgType, "messageType", 0, t.Header.MessageType, ErrInvalidMsgType)
 	}
 
-	if t.OriginTimestamp, err = originTimestamp2Time(b[HeaderLen:]); err != nil {
-		return err
+	if t.OriginTimestamp, err = originTimestamp2Time(b[HeaderLen : HeaderLen+OriginTimestampFullLen]); err != nil {
+		return decodeError(SyncMsgType, "originTimestamp", HeaderLen, nil, err)
 	}
 
-	return nil
+	return o.trailer(b[HeaderLen+SyncPayloadLen:], SyncMsgType, HeaderLen+SyncPayloadLen)
+}
+
+// Validate checks that a SyncMsg conforms to IEEE 1588.
+func (t *SyncMsg) Validate() error {
+	return t.Header.validate(SyncMsgType, t.MarshalLen(), false)
 }
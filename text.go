@@ -0,0 +1,597 @@
+package ptp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of enumerations as used by IEEE 1588. Values without a name are
+// formatted as hexadecimal numbers.
+var (
+	msgTypeNames = map[MsgType]string{
+		SyncMsgType:               "Sync",
+		DelayReqMsgType:           "Delay_Req",
+		PDelayReqMsgType:          "Pdelay_Req",
+		PDelayRespMsgType:         "Pdelay_Resp",
+		FollowUpMsgType:           "Follow_Up",
+		DelayRespMsgType:          "Delay_Resp",
+		PDelayRespFollowUpMsgType: "Pdelay_Resp_Follow_Up",
+		AnnounceMsgType:           "Announce",
+		SignalingMsgType:          "Signaling",
+		MgmtMsgType:               "Management",
+	}
+
+	portStateNames = map[PortState]string{
+		Initializing: "INITIALIZING",
+		Faulty:       "FAULTY",
+		Disabled:     "DISABLED",
+		Listening:    "LISTENING",
+		PreMaster:    "PRE_MASTER",
+		Master:       "MASTER",
+		Passive:      "PASSIVE",
+		Uncalibrated: "UNCALIBRATED",
+		Slave:        "SLAVE",
+	}
+
+	timeSourceNames = map[TimeSourceType]string{
+		TimeSourceAtomic:      "ATOMIC_CLOCK",
+		TimeSourceGPS:         "GPS",
+		TimeSourceTRadio:      "TERRESTRIAL_RADIO",
+		TimeSourcePTP:         "PTP",
+		TimeSourceNTP:         "NTP",
+		TimeSourceHandSet:     "HAND_SET",
+		TimeSourceOther:       "OTHER",
+		TimeSourceInternalOsc: "INTERNAL_OSCILLATOR",
+	}
+
+	clockAccuracyNames = map[ClockAccuracyType]string{
+		ClockAccuracy25ns:         "25ns",
+		ClockAccuracy100ns:        "100ns",
+		ClockAccuracy250ns:        "250ns",
+		ClockAccuracy1mics:        "1us",
+		ClockAccuracy2_5mics:      "2.5us",
+		ClockAccuracy10mics:       "10us",
+		ClockAccuracy25mics:       "25us",
+		ClockAccuracy100mics:      "100us",
+		ClockAccuracy250mics:      "250us",
+		ClockAccuracy1ms:          "1ms",
+		ClockAccuracy2_5ms:        "2.5ms",
+		ClockAccuracy10ms:         "10ms",
+		ClockAccuracy25ms:         "25ms",
+		ClockAccuracy100ms:        "100ms",
+		ClockAccuracy250ms:        "250ms",
+		ClockAccuracy1s:           "1s",
+		ClockAccuracy10s:          "10s",
+		ClockAccuracyMore10s:      ">10s",
+		ClockAccuracyNotSupported: "NotSupported",
+	}
+
+	managementIDNames = map[ManagementIdType]string{
+		NullManagement:                 "NULL_MANAGEMENT",
+		ClockDescription:               "CLOCK_DESCRIPTION",
+		UserDescription:                "USER_DESCRIPTION",
+		SaveInNonVolatileStorage:       "SAVE_IN_NON_VOLATILE_STORAGE",
+		ResetNonVolatileStorage:        "RESET_NON_VOLATILE_STORAGE",
+		Initialize:                     "INITIALIZE",
+		FaultLog:                       "FAULT_LOG",
+		FaultLogReset:                  "FAULT_LOG_RESET",
+		DefaultDataSet:                 "DEFAULT_DATA_SET",
+		CurrentDataSet:                 "CURRENT_DATA_SET",
+		ParentDataSet:                  "PARENT_DATA_SET",
+		TimePropertiesDataSet:          "TIME_PROPERTIES_DATA_SET",
+		PortDataSet:                    "PORT_DATA_SET",
+		Priority1:                      "PRIORITY1",
+		Priority2:                      "PRIORITY2",
+		Domain:                         "DOMAIN",
+		SlaveOnly:                      "SLAVE_ONLY",
+		LogAnnounceInterval:            "LOG_ANNOUNCE_INTERVAL",
+		AnnounceReceiptTimeout:         "ANNOUNCE_RECEIPT_TIMEOUT",
+		LogSyncInterval:                "LOG_SYNC_INTERVAL",
+		VersionNumber:                  "VERSION_NUMBER",
+		EneablePort:                    "ENABLE_PORT",
+		DisablePort:                    "DISABLE_PORT",
+		Time:                           "TIME",
+		ClockAccuracy:                  "CLOCK_ACCURACY",
+		UtcProperties:                  "UTC_PROPERTIES",
+		TraceabilityProperties:         "TRACEABILITY_PROPERTIES",
+		TimescaleProperties:            "TIMESCALE_PROPERTIES",
+		UnicastNegotiationEnable:       "UNICAST_NEGOTIATION_ENABLE",
+		PathTraceList:                  "PATH_TRACE_LIST",
+		PathTraceEnable:                "PATH_TRACE_ENABLE",
+		GrandMasterClusterTable:        "GRANDMASTER_CLUSTER_TABLE",
+		UnicastMasterTable:             "UNICAST_MASTER_TABLE",
+		UnicastMasterMaxTableSize:      "UNICAST_MASTER_MAX_TABLE_SIZE",
+		AcceptableMasterTable:          "ACCEPTABLE_MASTER_TABLE",
+		AcceptableMasterTableEnabled:   "ACCEPTABLE_MASTER_TABLE_ENABLED",
+		AcceptableMasterMaxTableSize:   "ACCEPTABLE_MASTER_MAX_TABLE_SIZE",
+		AlternateMaster:                "ALTERNATE_MASTER",
+		AlternateTimeOffsetEnable:      "ALTERNATE_TIME_OFFSET_ENABLE",
+		AlternateTimeOffsetName:        "ALTERNATE_TIME_OFFSET_NAME",
+		AlternateTimeOffsetMaxKey:      "ALTERNATE_TIME_OFFSET_MAX_KEY",
+		AlternateTimeOffsetProperties:  "ALTERNATE_TIME_OFFSET_PROPERTIES",
+		TransparentClockDefaultDataSet: "TRANSPARENT_CLOCK_DEFAULT_DATA_SET",
+		TransparentClockPortDataSet:    "TRANSPARENT_CLOCK_PORT_DATA_SET",
+		PrimaryDomain:                  "PRIMARY_DOMAIN",
+		DelayMechanism:                 "DELAY_MECHANISM",
+		LogMinPdelayReqInterval:        "LOG_MIN_PDELAY_REQ_INTERVAL",
+	}
+
+	actionFieldNames = map[ActionFiledType]string{
+		Get:         "GET",
+		Set:         "SET",
+		Response:    "RESPONSE",
+		Command:     "COMMAND",
+		Acknowledge: "ACKNOWLEDGE",
+	}
+
+	lockingStatusNames = map[MasterLockingStatusType]string{
+		LockingStatusNotInUse:    "NOT_IN_USE",
+		LockingStatusFreeRun:     "FREE_RUN",
+		LockingStatusColdLocking: "COLD_LOCKING",
+		LockingStatusWarmLocking: "WARM_LOCKING",
+		LockingStatusLocked:      "LOCKED",
+	}
+)
+
+// flagNames are the IEEE 1588 names of flagField bits
+var flagNames = []struct {
+	bit  uint16
+	name string
+}{
+	{alternateMasterBit, "alternateMasterFlag"},
+	{twoStepsBit, "twoStepFlag"},
+	{unicastBit, "unicastFlag"},
+	{profileSpecific1Bit, "profileSpecific1"},
+	{profileSpecific2Bit, "profileSpecific2"},
+	{securityBit, "security"},
+	{lI61Bit, "leap61"},
+	{lI59Bit, "leap59"},
+	{utcReasonableBit, "currentUtcOffsetValid"},
+	{timeScaleBit, "ptpTimescale"},
+	{timeTraceableBit, "timeTraceable"},
+	{frequencyTraceableBit, "frequencyTraceable"},
+}
+
+// enumString returns the name of an enumeration value, or the value in
+// hexadecimal if it has no name.
+func enumString(name string, ok bool, v uint64) string {
+	if ok {
+		return name
+	}
+	return "0x" + strconv.FormatUint(v, 16)
+}
+
+// parseEnum returns the value of an enumeration named s. Numbers are accepted
+// for values without a name.
+func parseEnum(s string, bitSize int, lookup func(string) (uint64, bool)) (uint64, error) {
+	if v, ok := lookup(s); ok {
+		return v, nil
+	}
+
+	v, err := strconv.ParseUint(s, 0, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("unknown value %q", s)
+	}
+
+	return v, nil
+}
+
+// String returns the IEEE 1588 name of the message type.
+func (t MsgType) String() string {
+	name, ok := msgTypeNames[t]
+	return enumString(name, ok, uint64(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t MsgType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *MsgType) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 4, func(s string) (uint64, bool) {
+		for k, name := range msgTypeNames {
+			if name == s {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*t = MsgType(v)
+	return err
+}
+
+// String returns the IEEE 1588 name of the port state.
+func (s PortState) String() string {
+	name, ok := portStateNames[s]
+	return enumString(name, ok, uint64(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PortState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PortState) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 8, func(str string) (uint64, bool) {
+		for k, name := range portStateNames {
+			if name == str {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*s = PortState(v)
+	return err
+}
+
+// String returns the IEEE 1588 name of the time source.
+func (t TimeSourceType) String() string {
+	name, ok := timeSourceNames[t]
+	return enumString(name, ok, uint64(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeSourceType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeSourceType) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 8, func(s string) (uint64, bool) {
+		for k, name := range timeSourceNames {
+			if name == s {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*t = TimeSourceType(v)
+	return err
+}
+
+// String returns the accuracy the clockAccuracy value stands for, such as
+// "250ns".
+func (c ClockAccuracyType) String() string {
+	name, ok := clockAccuracyNames[c]
+	return enumString(name, ok, uint64(c))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ClockAccuracyType) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *ClockAccuracyType) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 8, func(s string) (uint64, bool) {
+		for k, name := range clockAccuracyNames {
+			if name == s {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*c = ClockAccuracyType(v)
+	return err
+}
+
+// String returns the IEEE 1588 name of the managementId.
+func (m ManagementIdType) String() string {
+	name, ok := managementIDNames[m]
+	return enumString(name, ok, uint64(m))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m ManagementIdType) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *ManagementIdType) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 16, func(s string) (uint64, bool) {
+		for k, name := range managementIDNames {
+			if name == s {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*m = ManagementIdType(v)
+	return err
+}
+
+// String returns the IEEE 1588 name of the actionField.
+func (a ActionFiledType) String() string {
+	name, ok := actionFieldNames[a]
+	return enumString(name, ok, uint64(a))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a ActionFiledType) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *ActionFiledType) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 4, func(s string) (uint64, bool) {
+		for k, name := range actionFieldNames {
+			if name == s {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*a = ActionFiledType(v)
+	return err
+}
+
+// String returns the SMPTE ST 2059-2 name of the masterLockingStatus.
+func (l MasterLockingStatusType) String() string {
+	name, ok := lockingStatusNames[l]
+	return enumString(name, ok, uint64(l))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l MasterLockingStatusType) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *MasterLockingStatusType) UnmarshalText(b []byte) error {
+	v, err := parseEnum(string(b), 8, func(s string) (uint64, bool) {
+		for k, name := range lockingStatusNames {
+			if name == s {
+				return uint64(k), true
+			}
+		}
+		return 0, false
+	})
+	*l = MasterLockingStatusType(v)
+	return err
+}
+
+// String returns the names of the flags set, separated by '|'.
+func (f Flags) String() string {
+	return strings.Join(f.names(), "|")
+}
+
+// names returns the IEEE 1588 names of the flags set.
+func (f Flags) names() []string {
+	bits := f.MarshalBinary()
+
+	names := []string{}
+	for _, n := range flagNames {
+		if bits&n.bit != 0 {
+			names = append(names, n.name)
+		}
+	}
+
+	return names
+}
+
+// setNames sets the flags named in names and clears the others.
+func (f *Flags) setNames(names []string) error {
+	var bits uint16
+
+next:
+	for _, s := range names {
+		for _, n := range flagNames {
+			if n.name == s {
+				bits |= n.bit
+				continue next
+			}
+		}
+		return fmt.Errorf("unknown flag %q", s)
+	}
+
+	return f.UnmarshalBinary([]byte{byte(bits >> 8), byte(bits)})
+}
+
+// formatClockIdentity formats a clockIdentity as in "000af7.fffe.42a753".
+func formatClockIdentity(id uint64) string {
+	return fmt.Sprintf("%06x.%04x.%06x", id>>40, id>>24&0xffff, id&0xffffff)
+}
+
+// parseClockIdentity parses a clockIdentity formatted by formatClockIdentity.
+func parseClockIdentity(s string) (uint64, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 || len(parts[0]) != 6 || len(parts[1]) != 4 || len(parts[2]) != 6 {
+		return 0, fmt.Errorf("invalid clockIdentity %q", s)
+	}
+
+	id, err := strconv.ParseUint(parts[0]+parts[1]+parts[2], 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clockIdentity %q", s)
+	}
+
+	return id, nil
+}
+
+// formatPortIdentity formats a portIdentity as in "000af7.fffe.42a753-1".
+func formatPortIdentity(id uint64, port uint16) string {
+	return formatClockIdentity(id) + "-" + strconv.Itoa(int(port))
+}
+
+// formatTimestamp formats a timestamp as seconds and nanoseconds of the
+// PTP timescale.
+func formatTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+// String returns a summary of the header.
+func (h Header) String() string {
+	s := fmt.Sprintf("%v sourcePortIdentity=%s sequenceId=%d", h.MessageType,
+		formatPortIdentity(h.ClockIdentity, h.PortNumber), h.SequenceID)
+
+	if flags := h.Flags.String(); flags != "" {
+		s += " flagField=" + flags
+	}
+
+	return s + fmt.Sprintf(" correctionField=%d logMessageInterval=%d", h.correctionField(), h.LogMessagePeriod)
+}
+
+// correctionField returns the correction in nanoseconds multiplied by 2^16.
+func (h *Header) correctionField() int64 {
+	return int64(h.CorrectionNs<<16 | uint64(h.CorrectionSubNs))
+}
+
+// setCorrectionField sets the correction from nanoseconds multiplied by 2^16.
+func (h *Header) setCorrectionField(v int64) {
+	h.CorrectionNs = uint64(v) >> 16 & 0xffffffffffff
+	h.CorrectionSubNs = uint16(v)
+}
+
+// String returns the clockClass, clockAccuracy and offsetScaledLogVariance.
+func (p ClockQuality) String() string {
+	return fmt.Sprintf("%d/%v/0x%04x", p.ClockClass, p.ClockAccuracy, p.ClockVariance)
+}
+
+// String returns a summary of the message.
+func (t SyncMsg) String() string {
+	return t.Header.String() + " originTimestamp=" + formatTimestamp(t.OriginTimestamp)
+}
+
+// String returns a summary of the message.
+func (t DelReqMsg) String() string {
+	return t.Header.String() + " originTimestamp=" + formatTimestamp(t.OriginTimestamp)
+}
+
+// String returns a summary of the message.
+func (t FollowUpMsg) String() string {
+	return t.Header.String() + " preciseOriginTimestamp=" + formatTimestamp(t.PreciseOriginTimestamp)
+}
+
+// String returns a summary of the message.
+func (t DelRespMsg) String() string {
+	return t.Header.String() +
+		" receiveTimestamp=" + formatTimestamp(t.ReceiveTimestamp) +
+		" requestingPortIdentity=" + formatPortIdentity(t.RequestingPortIdentity, t.RequestingPortID)
+}
+
+// String returns a summary of the message.
+func (t PDelReqMsg) String() string {
+	return t.Header.String()
+}
+
+// String returns a summary of the message.
+func (t PDelRespMsg) String() string {
+	return t.Header.String() +
+		" requestReceiptTimestamp=" + formatTimestamp(t.ReceiveTimestamp) +
+		" requestingPortIdentity=" + formatPortIdentity(t.ClockIdentity, t.PortNumber)
+}
+
+// String returns a summary of the message.
+func (t PDelRespFollowUpMsg) String() string {
+	return t.Header.String() +
+		" responseOriginTimestamp=" + formatTimestamp(t.OriginTimestamp) +
+		" requestingPortIdentity=" + formatPortIdentity(t.ClockIdentity, t.PortNumber)
+}
+
+// String returns a summary of the message.
+func (t AnnounceMsg) String() string {
+	s := t.Header.String() + fmt.Sprintf(" currentUtcOffset=%d grandmasterPriority1=%d grandmasterClockQuality=%v"+
+		" grandmasterPriority2=%d grandmasterIdentity=%s stepsRemoved=%d timeSource=%v",
+		t.CurrentUtcOffset, t.GMPriority1, t.GMClockQuality, t.GMPriority2,
+		formatClockIdentity(t.GMIdentity), t.StepsRemoved, t.TimeSource)
+
+	if len(t.PathTraceTlv.pathSequence) > 0 {
+		s += " " + t.PathTraceTlv.String()
+	}
+	if t.PowerProfile != nil {
+		s += " " + t.PowerProfile.String()
+	}
+	if t.PowerProfile2011 != nil {
+		s += " " + t.PowerProfile2011.String()
+	}
+
+	return s
+}
+
+// String returns a summary of the message.
+func (t SignalingMsg) String() string {
+	return t.Header.String() +
+		" targetPortIdentity=" + formatPortIdentity(t.ClockIdentity, t.PortNumber) +
+		" " + t.IntervalRequestTlv.String()
+}
+
+// String returns a summary of the message.
+func (t MgmtMsg) String() string {
+	s := t.Header.String() + fmt.Sprintf(" targetPortIdentity=%s startingBoundaryHops=%d boundaryHops=%d actionField=%v",
+		formatPortIdentity(t.ClockIdentity, t.PortNumber), t.StartingBoundaryHops, t.BoundaryHops, t.ActionField)
+
+	if t.SmpteSync != nil {
+		s += " " + t.SmpteSync.String()
+	}
+
+	return s
+}
+
+// String returns the TLV type and its fields.
+func (p PathTraceTlv) String() string {
+	ids := make([]string, len(p.pathSequence))
+	for i, id := range p.pathSequence {
+		ids[i] = formatClockIdentity(id)
+	}
+	return "PATH_TRACE pathSequence=[" + strings.Join(ids, " ") + "]"
+}
+
+// String returns the TLV type and its fields.
+func (p IntervalRequestTlv) String() string {
+	return fmt.Sprintf("MESSAGE_INTERVAL_REQUEST linkDelayInterval=%d timeSyncInterval=%d announceInterval=%d"+
+		" computeNeighborRateRatio=%t computeNeighborPropDelay=%t",
+		p.LinkDelayInterval, p.TimeSyncInterval, p.AnnounceInterval,
+		p.ComputeNeighborRateRatio, p.ComputeNeighborPropDelay)
+}
+
+// String returns the TLV type and its fields.
+func (p FollowUpTlv) String() string {
+	return fmt.Sprintf("FOLLOW_UP cumulativeScaledRateOffset=%d gmTimeBaseIndicator=%d"+
+		" lastGmPhaseChange=%v scaledLastGmFreqChange=%d",
+		p.CumulativeScaledRateOffset, p.GmTimeBaseIndicator, p.LastGmPhaseChange, p.ScaledLastGmFreqChange)
+}
+
+// String returns the TLV type and its fields.
+func (p CsnTlv) String() string {
+	return fmt.Sprintf("CSN upstreamTxTime=%v neighborRateRatio=%d neighborPropDelay=%v delayAsymmetry=%v",
+		p.UpstreamTxTime, p.NeighborRateRatio, p.NeighborPropDelay, p.DelayAsymmetry)
+}
+
+// String returns the TLV type and its fields.
+func (p PowerProfileTlv) String() string {
+	return fmt.Sprintf("IEEE_C37_238 grandmasterID=%d totalTimeInaccuracy=%d", p.GrandmasterID, p.TotalTimeInaccuracy)
+}
+
+// String returns the TLV type and its fields.
+func (p PowerProfile2011Tlv) String() string {
+	return fmt.Sprintf("IEEE_C37_238 grandmasterID=%d grandmasterTimeInaccuracy=%d networkTimeInaccuracy=%d",
+		p.GrandmasterID, p.GrandmasterTimeInaccuracy, p.NetworkTimeInaccuracy)
+}
+
+// String returns the TLV type and its fields.
+func (p SmpteSyncTlv) String() string {
+	return fmt.Sprintf("SMPTE_SYNC defaultSystemFrameRate=%d/%d masterLockingStatus=%v"+
+		" currentLocalOffset=%d jumpSeconds=%d timeOfNextJump=%d timeOfNextJam=%d"+
+		" timeOfPreviousJam=%d previousJamLocalOffset=%d leapSecondJump=%t",
+		p.DefaultSystemFrameRate.Numerator, p.DefaultSystemFrameRate.Denominator, p.MasterLockingStatus,
+		p.CurrentLocalOffset/time.Second, p.JumpSeconds/time.Second, p.TimeOfNextJump.Unix(),
+		p.TimeOfNextJam.Unix(), p.TimeOfPreviousJam.Unix(), p.PreviousJamLocalOffset/time.Second,
+		p.LeapSecondJump)
+}
+
+// String returns the TLV type and its fields. It is defined so that the method
+// of the embedded ClockQuality doesn't drop the other fields.
+func (p DefaultDataSetTlv) String() string {
+	return fmt.Sprintf("DEFAULT_DATA_SET twoStepFlag=%t slaveOnly=%t numberPorts=%d priority1=%d"+
+		" clockQuality=%v priority2=%d clockIdentity=%s domainNumber=%d",
+		p.TSC, p.SO, p.NumberPorts, p.Priority1, p.ClockQuality, p.Priority2,
+		formatClockIdentity(p.ClockIdentity), p.DomainNumber)
+}
+
+// String returns the value in nanoseconds multiplied by 2^16 in hexadecimal.
+func (p UScaledNs) String() string {
+	return fmt.Sprintf("0x%x%016x", uint32(p.ms), p.ls)
+}
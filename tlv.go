@@ -9,13 +9,26 @@ import (
 
 // TLV payload length
 const (
-	FollowUpTlvLen        = 28
-	IntervalRequestTlvLen = 12
-	CsnTlvLen             = 46
+	FollowUpTlvLen         = 28
+	IntervalRequestTlvLen  = 12
+	CsnTlvLen              = 46
+	PowerProfileTlvLen     = 16
+	PowerProfile2011TlvLen = 18
+	SmpteSyncTlvLen        = 48
 )
 
 var organizationID = []byte{0x0, 0x80, 0xc2}
 
+// ieeeC37238OrganizationID is the OUI assigned to IEEE C37.238
+var ieeeC37238OrganizationID = []byte{0x1c, 0x12, 0x9d}
+
+// smpteOrganizationID is the OUI assigned to SMPTE
+var smpteOrganizationID = []byte{0x68, 0x97, 0xe8}
+
+// UnknownTimeInaccuracy is the value of time inaccuracy fields of IEEE C37.238
+// TLVs when the inaccuracy is unknown or exceeds the representable range.
+const UnknownTimeInaccuracy uint32 = 0xffffffff
+
 // TlvType Type
 type TlvType uint16
 
@@ -59,7 +72,7 @@ const (
 // RequestUnicastTransmissionTlv...
 type RequestUnicastTransmissionTlv struct {
 	MsgTypeValue          MsgType
-	LogInterMessagePeriod int8
+	LogInterMessagePeriod LogInterval
 	DurationField         uint32
 }
 
@@ -71,7 +84,32 @@ type PathTraceTlv struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (p *PathTraceTlv) MarshalBinary() ([]byte, error) {
 
-	b := make([]byte, 4+8*len(p.pathSequence))
+	b := make([]byte, p.MarshalLen())
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a PathTraceTlv in binary form.
+func (p *PathTraceTlv) MarshalLen() int {
+	return 4 + 8*len(p.pathSequence)
+}
+
+// AppendBinary appends the binary form of a PathTraceTlv to b.
+func (p *PathTraceTlv) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, p.MarshalLen(), p.MarshalTo)
+}
+
+// MarshalTo marshals a PathTraceTlv into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *PathTraceTlv) MarshalTo(b []byte) (int, error) {
+	n := p.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
 
 	// TLV type
 	binary.BigEndian.PutUint16(b[:2], uint16(PathTrace))
@@ -83,7 +121,7 @@ func (p *PathTraceTlv) MarshalBinary() ([]byte, error) {
 		binary.BigEndian.PutUint64(b[4+i*8:4+i*8+8], v)
 	}
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a PathTraceTlv.
@@ -120,9 +158,9 @@ func (p *PathTraceTlv) UnmarshalBinary(b []byte) error {
 // IntervalRequestTlv ...
 type IntervalRequestTlv struct {
 	// OrganizationSubType = 2
-	LinkDelayInterval        int8
-	TimeSyncInterval         int8
-	AnnounceInterval         int8
+	LinkDelayInterval        LogInterval
+	TimeSyncInterval         LogInterval
+	AnnounceInterval         LogInterval
 	ComputeNeighborRateRatio bool
 	ComputeNeighborPropDelay bool
 }
@@ -130,7 +168,32 @@ type IntervalRequestTlv struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (p *IntervalRequestTlv) MarshalBinary() ([]byte, error) {
 
-	b := make([]byte, IntervalRequestTlvLen+4)
+	b := make([]byte, p.MarshalLen())
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a IntervalRequestTlv in binary form.
+func (p *IntervalRequestTlv) MarshalLen() int {
+	return IntervalRequestTlvLen + 4
+}
+
+// AppendBinary appends the binary form of a IntervalRequestTlv to b.
+func (p *IntervalRequestTlv) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, p.MarshalLen(), p.MarshalTo)
+}
+
+// MarshalTo marshals a IntervalRequestTlv into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *IntervalRequestTlv) MarshalTo(b []byte) (int, error) {
+	n := p.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
 
 	// TLV type
 	binary.BigEndian.PutUint16(b[:2], uint16(OrganizationExtension))
@@ -151,7 +214,10 @@ func (p *IntervalRequestTlv) MarshalBinary() ([]byte, error) {
 
 	b[13] = uint8(b2i(p.ComputeNeighborRateRatio)<<1 | b2i(p.ComputeNeighborPropDelay)<<2)
 
-	return b, nil
+	// Reserved 2 bytes
+	zero(b[14:16])
+
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a IntervalRequestTlv.
@@ -182,11 +248,11 @@ func (p *IntervalRequestTlv) UnmarshalBinary(b []byte) error {
 		return ErrInvalidTlvOrgSubType
 	}
 
-	p.LinkDelayInterval = int8(b[10])
+	p.LinkDelayInterval = LogInterval(b[10])
 
-	p.TimeSyncInterval = int8(b[11])
+	p.TimeSyncInterval = LogInterval(b[11])
 
-	p.AnnounceInterval = int8(b[12])
+	p.AnnounceInterval = LogInterval(b[12])
 
 	p.ComputeNeighborRateRatio = (b[13] & 0x2) != 0
 	p.ComputeNeighborPropDelay = (b[13] & 0x4) != 0
@@ -206,7 +272,32 @@ type FollowUpTlv struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (p *FollowUpTlv) MarshalBinary() ([]byte, error) {
 
-	b := make([]byte, FollowUpTlvLen+4)
+	b := make([]byte, p.MarshalLen())
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a FollowUpTlv in binary form.
+func (p *FollowUpTlv) MarshalLen() int {
+	return FollowUpTlvLen + 4
+}
+
+// AppendBinary appends the binary form of a FollowUpTlv to b.
+func (p *FollowUpTlv) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, p.MarshalLen(), p.MarshalTo)
+}
+
+// MarshalTo marshals a FollowUpTlv into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *FollowUpTlv) MarshalTo(b []byte) (int, error) {
+	n := p.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
 
 	// TLV type
 	binary.BigEndian.PutUint16(b[:2], uint16(OrganizationExtension))
@@ -226,16 +317,14 @@ func (p *FollowUpTlv) MarshalBinary() ([]byte, error) {
 	binary.BigEndian.PutUint16(b[offset:offset+2], p.GmTimeBaseIndicator)
 	offset += 2
 
-	lastGM, err := p.LastGmPhaseChange.MarshalBinary()
-	if err != nil {
-		return nil, err
+	if _, err := p.LastGmPhaseChange.MarshalTo(b[offset : offset+UScaledNsLen]); err != nil {
+		return 0, err
 	}
-	copy(b[offset:offset+UScaledNsLen], lastGM)
 	offset += UScaledNsLen
 
 	binary.BigEndian.PutUint32(b[offset:offset+4], uint32(p.ScaledLastGmFreqChange))
 
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a FollowUpTlv.
@@ -294,7 +383,32 @@ type CsnTlv struct {
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
 func (p *CsnTlv) MarshalBinary() ([]byte, error) {
 
-	b := make([]byte, CsnTlvLen+4)
+	b := make([]byte, p.MarshalLen())
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a CsnTlv in binary form.
+func (p *CsnTlv) MarshalLen() int {
+	return CsnTlvLen + 4
+}
+
+// AppendBinary appends the binary form of a CsnTlv to b.
+func (p *CsnTlv) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, p.MarshalLen(), p.MarshalTo)
+}
+
+// MarshalTo marshals a CsnTlv into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *CsnTlv) MarshalTo(b []byte) (int, error) {
+	n := p.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
 
 	// TLV type
 	binary.BigEndian.PutUint16(b[:2], uint16(OrganizationExtension))
@@ -307,35 +421,26 @@ func (p *CsnTlv) MarshalBinary() ([]byte, error) {
 	// organizationSubType
 	copy(b[7:10], []byte{0x0, 0x0, 0x3})
 
-	tx, err := p.UpstreamTxTime.MarshalBinary()
-	if err != nil {
-		return nil, err
-	}
-
 	offset := 10
 
-	copy(b[offset:offset+UScaledNsLen], tx)
+	if _, err := p.UpstreamTxTime.MarshalTo(b[offset : offset+UScaledNsLen]); err != nil {
+		return 0, err
+	}
 	offset += UScaledNsLen
 
 	binary.BigEndian.PutUint32(b[offset:offset+4], uint32(p.NeighborRateRatio))
 	offset += 4
 
-	nd, err := p.NeighborPropDelay.MarshalBinary()
-	if err != nil {
-		return nil, err
+	if _, err := p.NeighborPropDelay.MarshalTo(b[offset : offset+UScaledNsLen]); err != nil {
+		return 0, err
 	}
-
-	copy(b[offset:offset+UScaledNsLen], nd)
 	offset += UScaledNsLen
 
-	da, err := p.DelayAsymmetry.MarshalBinary()
-	if err != nil {
-		return nil, err
+	if _, err := p.DelayAsymmetry.MarshalTo(b[offset : offset+UScaledNsLen]); err != nil {
+		return 0, err
 	}
 
-	copy(b[offset:offset+UScaledNsLen], da)
-
-	return b, nil
+	return n, nil
 }
 
 // UnmarshalBinary unmarshals a byte slice into a CsnTlv frame.
@@ -396,6 +501,465 @@ func (p *CsnTlv) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// PowerProfileTlv is the IEEE_C37_238 TLV of IEEE C37.238-2017 carried in
+// Announce messages.
+type PowerProfileTlv struct {
+	// OrganizationSubType = 2
+	GrandmasterID uint16
+	// Reserved 4 bytes
+	TotalTimeInaccuracy uint32
+}
+
+// MarshalBinary allocates a byte slice and marshals a Frame into binary form.
+func (p *PowerProfileTlv) MarshalBinary() ([]byte, error) {
+
+	b := make([]byte, p.MarshalLen())
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a PowerProfileTlv in binary form.
+func (p *PowerProfileTlv) MarshalLen() int {
+	return PowerProfileTlvLen + 4
+}
+
+// AppendBinary appends the binary form of a PowerProfileTlv to b.
+func (p *PowerProfileTlv) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, p.MarshalLen(), p.MarshalTo)
+}
+
+// MarshalTo marshals a PowerProfileTlv into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *PowerProfileTlv) MarshalTo(b []byte) (int, error) {
+	n := p.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
+
+	// TLV type
+	binary.BigEndian.PutUint16(b[:2], uint16(OrganizationExtension))
+
+	// TLV length
+	binary.BigEndian.PutUint16(b[2:4], uint16(PowerProfileTlvLen))
+
+	copy(b[4:7], ieeeC37238OrganizationID)
+
+	// organizationSubType
+	copy(b[7:10], []byte{0x0, 0x0, 0x2})
+
+	binary.BigEndian.PutUint16(b[10:12], p.GrandmasterID)
+
+	// Reserved 4 bytes
+	zero(b[12:16])
+
+	binary.BigEndian.PutUint32(b[16:20], p.TotalTimeInaccuracy)
+
+	return n, nil
+}
+
+// UnmarshalBinary unmarshals a byte slice into a PowerProfileTlv.
+//
+// If the byte slice does not contain enough data to unmarshal a valid PowerProfileTlv,
+// io.ErrUnexpectedEOF is returned.
+func (p *PowerProfileTlv) UnmarshalBinary(b []byte) error {
+	if len(b) != (PowerProfileTlvLen + 4) {
+		return io.ErrUnexpectedEOF
+	}
+
+	tlvLen := binary.BigEndian.Uint16(b[2:4])
+	if int(tlvLen) != PowerProfileTlvLen {
+		return io.ErrUnexpectedEOF
+	}
+
+	tlvType := TlvType(binary.BigEndian.Uint16(b[0:2]))
+	if tlvType != OrganizationExtension {
+		return ErrInvalidTlvType
+	}
+
+	if !bytes.Equal(b[4:7], ieeeC37238OrganizationID) {
+		return ErrInvalidTlvOrgId
+	}
+
+	// The value of organizationSubType is 2
+	if !bytes.Equal([]byte{0x0, 0x0, 0x2}, b[7:10]) {
+		return ErrInvalidTlvOrgSubType
+	}
+
+	p.GrandmasterID = binary.BigEndian.Uint16(b[10:12])
+
+	p.TotalTimeInaccuracy = binary.BigEndian.Uint32(b[16:20])
+
+	return nil
+}
+
+// AddTimeInaccuracy accumulates the time inaccuracy in nanoseconds introduced
+// by a boundary clock forwarding the Announce information.
+func (p *PowerProfileTlv) AddTimeInaccuracy(ns uint32) {
+	p.TotalTimeInaccuracy = addTimeInaccuracy(p.TotalTimeInaccuracy, ns)
+}
+
+// PowerProfile2011Tlv is the IEEE_C37_238 TLV of IEEE C37.238-2011 carried in
+// Announce messages.
+type PowerProfile2011Tlv struct {
+	// OrganizationSubType = 1
+	GrandmasterID             uint16
+	GrandmasterTimeInaccuracy uint32
+	NetworkTimeInaccuracy     uint32
+	// Reserved 2 bytes
+}
+
+// MarshalBinary allocates a byte slice and marshals a Frame into binary form.
+func (p *PowerProfile2011Tlv) MarshalBinary() ([]byte, error) {
+
+	b := make([]byte, p.MarshalLen())
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a PowerProfile2011Tlv in binary form.
+func (p *PowerProfile2011Tlv) MarshalLen() int {
+	return PowerProfile2011TlvLen + 4
+}
+
+// AppendBinary appends the binary form of a PowerProfile2011Tlv to b.
+func (p *PowerProfile2011Tlv) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, p.MarshalLen(), p.MarshalTo)
+}
+
+// MarshalTo marshals a PowerProfile2011Tlv into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *PowerProfile2011Tlv) MarshalTo(b []byte) (int, error) {
+	n := p.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
+
+	// TLV type
+	binary.BigEndian.PutUint16(b[:2], uint16(OrganizationExtension))
+
+	// TLV length
+	binary.BigEndian.PutUint16(b[2:4], uint16(PowerProfile2011TlvLen))
+
+	copy(b[4:7], ieeeC37238OrganizationID)
+
+	// organizationSubType
+	copy(b[7:10], []byte{0x0, 0x0, 0x1})
+
+	binary.BigEndian.PutUint16(b[10:12], p.GrandmasterID)
+
+	binary.BigEndian.PutUint32(b[12:16], p.GrandmasterTimeInaccuracy)
+
+	binary.BigEndian.PutUint32(b[16:20], p.NetworkTimeInaccuracy)
+
+	// Reserved 2 bytes
+	zero(b[20:22])
+
+	return n, nil
+}
+
+// UnmarshalBinary unmarshals a byte slice into a PowerProfile2011Tlv.
+//
+// If the byte slice does not contain enough data to unmarshal a valid PowerProfile2011Tlv,
+// io.ErrUnexpectedEOF is returned.
+func (p *PowerProfile2011Tlv) UnmarshalBinary(b []byte) error {
+	if len(b) != (PowerProfile2011TlvLen + 4) {
+		return io.ErrUnexpectedEOF
+	}
+
+	tlvLen := binary.BigEndian.Uint16(b[2:4])
+	if int(tlvLen) != PowerProfile2011TlvLen {
+		return io.ErrUnexpectedEOF
+	}
+
+	tlvType := TlvType(binary.BigEndian.Uint16(b[0:2]))
+	if tlvType != OrganizationExtension {
+		return ErrInvalidTlvType
+	}
+
+	if !bytes.Equal(b[4:7], ieeeC37238OrganizationID) {
+		return ErrInvalidTlvOrgId
+	}
+
+	// The value of organizationSubType is 1
+	if !bytes.Equal([]byte{0x0, 0x0, 0x1}, b[7:10]) {
+		return ErrInvalidTlvOrgSubType
+	}
+
+	p.GrandmasterID = binary.BigEndian.Uint16(b[10:12])
+
+	p.GrandmasterTimeInaccuracy = binary.BigEndian.Uint32(b[12:16])
+
+	p.NetworkTimeInaccuracy = binary.BigEndian.Uint32(b[16:20])
+
+	return nil
+}
+
+// AddNetworkTimeInaccuracy accumulates the time inaccuracy in nanoseconds
+// introduced by a boundary clock forwarding the Announce information.
+func (p *PowerProfile2011Tlv) AddNetworkTimeInaccuracy(ns uint32) {
+	p.NetworkTimeInaccuracy = addTimeInaccuracy(p.NetworkTimeInaccuracy, ns)
+}
+
+// TotalTimeInaccuracy returns the sum of grandmaster and network time
+// inaccuracies in nanoseconds.
+func (p *PowerProfile2011Tlv) TotalTimeInaccuracy() uint32 {
+	return addTimeInaccuracy(p.GrandmasterTimeInaccuracy, p.NetworkTimeInaccuracy)
+}
+
+// addTimeInaccuracy sums two time inaccuracies, saturating at
+// UnknownTimeInaccuracy. An unknown inaccuracy stays unknown.
+func addTimeInaccuracy(a, b uint32) uint32 {
+	if a == UnknownTimeInaccuracy || b == UnknownTimeInaccuracy {
+		return UnknownTimeInaccuracy
+	}
+
+	if sum := uint64(a) + uint64(b); sum < uint64(UnknownTimeInaccuracy) {
+		return uint32(sum)
+	}
+
+	return UnknownTimeInaccuracy
+}
+
+// FrameRate is a video frame rate expressed as a rational number of frames
+// per second.
+type FrameRate struct {
+	Numerator   uint32
+	Denominator uint32
+}
+
+// Common frame rates
+var (
+	FrameRate24    = FrameRate{24, 1}
+	FrameRate23_98 = FrameRate{24000, 1001}
+	FrameRate25    = FrameRate{25, 1}
+	FrameRate30    = FrameRate{30, 1}
+	FrameRate29_97 = FrameRate{30000, 1001}
+	FrameRate50    = FrameRate{50, 1}
+	FrameRate60    = FrameRate{60, 1}
+	FrameRate59_94 = FrameRate{60000, 1001}
+)
+
+// FramesPerSecond returns the frame rate as a floating point number. Zero is
+// returned if the denominator is zero.
+func (r FrameRate) FramesPerSecond() float64 {
+	if r.Denominator == 0 {
+		return 0
+	}
+	return float64(r.Numerator) / float64(r.Denominator)
+}
+
+// FrameDuration returns the duration of a single frame. Zero is returned if
+// the numerator is zero.
+func (r FrameRate) FrameDuration() time.Duration {
+	if r.Numerator == 0 {
+		return 0
+	}
+	return time.Duration(uint64(r.Denominator) * uint64(time.Second) / uint64(r.Numerator))
+}
+
+// MasterLockingStatusType is masterLockingStatus of SMPTE ST 2059-2
+// synchronization metadata.
+type MasterLockingStatusType uint8
+
+// MasterLockingStatus types codes
+const (
+	LockingStatusNotInUse    MasterLockingStatusType = 0
+	LockingStatusFreeRun     MasterLockingStatusType = 1
+	LockingStatusColdLocking MasterLockingStatusType = 2
+	LockingStatusWarmLocking MasterLockingStatusType = 3
+	LockingStatusLocked      MasterLockingStatusType = 4
+)
+
+// SmpteSyncTlv is the SMPTE ST 2059-2 synchronization metadata TLV. It is
+// carried in Management messages.
+//
+// Jump and jam times are expressed in the PTP timescale, offsets and jumps
+// are whole seconds. A zero jump or jam time is sent as 0 seconds, and 0
+// seconds are received as a zero time.
+type SmpteSyncTlv struct {
+	// OrganizationSubType = 1
+	DefaultSystemFrameRate FrameRate
+	MasterLockingStatus    MasterLockingStatusType
+	// timeAddressFlags
+	DropFrame                bool
+	ColorFrameIdentification bool
+	CurrentLocalOffset       time.Duration
+	JumpSeconds              time.Duration
+	TimeOfNextJump           time.Time
+	TimeOfNextJam            time.Time
+	TimeOfPreviousJam        time.Time
+	PreviousJamLocalOffset   time.Duration
+	// daylightSaving
+	CurrentDaylightSaving       bool
+	DaylightSavingAtNextJump    bool
+	DaylightSavingAtPreviousJam bool
+	// leapSecondJump
+	LeapSecondJump bool
+}
+
+// MarshalBinary allocates a byte slice and marshals a Frame into binary form.
+func (p *SmpteSyncTlv) MarshalBinary() ([]byte, error) {
+
+	b := make([]byte, p.MarshalLen())
+
+	if _, err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalLen returns the length of a SmpteSyncTlv in binary form.
+func (p *SmpteSyncTlv) MarshalLen() int {
+	return SmpteSyncTlvLen + 4
+}
+
+// AppendBinary appends the binary form of a SmpteSyncTlv to b.
+func (p *SmpteSyncTlv) AppendBinary(b []byte) ([]byte, error) {
+	return appendMarshal(b, p.MarshalLen(), p.MarshalTo)
+}
+
+// MarshalTo marshals a SmpteSyncTlv into b and returns the number of bytes
+// written. If b is too short, io.ErrShortBuffer is returned.
+func (p *SmpteSyncTlv) MarshalTo(b []byte) (int, error) {
+	n := p.MarshalLen()
+	if len(b) < n {
+		return 0, io.ErrShortBuffer
+	}
+
+	// TLV type
+	binary.BigEndian.PutUint16(b[:2], uint16(OrganizationExtension))
+
+	// TLV length
+	binary.BigEndian.PutUint16(b[2:4], uint16(SmpteSyncTlvLen))
+
+	copy(b[4:7], smpteOrganizationID)
+
+	// organizationSubType
+	copy(b[7:10], []byte{0x0, 0x0, 0x1})
+	offset := 10
+
+	binary.BigEndian.PutUint32(b[offset:offset+4], p.DefaultSystemFrameRate.Numerator)
+	offset += 4
+
+	binary.BigEndian.PutUint32(b[offset:offset+4], p.DefaultSystemFrameRate.Denominator)
+	offset += 4
+
+	b[offset] = uint8(p.MasterLockingStatus)
+	offset++
+
+	b[offset] = uint8(b2i(p.DropFrame) | b2i(p.ColorFrameIdentification)<<1)
+	offset++
+
+	binary.BigEndian.PutUint32(b[offset:offset+4], uint32(int32(p.CurrentLocalOffset/time.Second)))
+	offset += 4
+
+	binary.BigEndian.PutUint32(b[offset:offset+4], uint32(int32(p.JumpSeconds/time.Second)))
+	offset += 4
+
+	putSmpteTime(b[offset:offset+6], p.TimeOfNextJump)
+	offset += 6
+
+	putSmpteTime(b[offset:offset+6], p.TimeOfNextJam)
+	offset += 6
+
+	putSmpteTime(b[offset:offset+6], p.TimeOfPreviousJam)
+	offset += 6
+
+	binary.BigEndian.PutUint32(b[offset:offset+4], uint32(int32(p.PreviousJamLocalOffset/time.Second)))
+	offset += 4
+
+	b[offset] = uint8(b2i(p.CurrentDaylightSaving) |
+		b2i(p.DaylightSavingAtNextJump)<<1 |
+		b2i(p.DaylightSavingAtPreviousJam)<<2)
+	offset++
+
+	b[offset] = uint8(b2i(p.LeapSecondJump))
+
+	return n, nil
+}
+
+// UnmarshalBinary unmarshals a byte slice into a SmpteSyncTlv.
+//
+// If the byte slice does not contain enough data to unmarshal a valid SmpteSyncTlv,
+// io.ErrUnexpectedEOF is returned.
+func (p *SmpteSyncTlv) UnmarshalBinary(b []byte) error {
+	if len(b) != (SmpteSyncTlvLen + 4) {
+		return io.ErrUnexpectedEOF
+	}
+
+	tlvLen := binary.BigEndian.Uint16(b[2:4])
+	if int(tlvLen) != SmpteSyncTlvLen {
+		return io.ErrUnexpectedEOF
+	}
+
+	tlvType := TlvType(binary.BigEndian.Uint16(b[0:2]))
+	if tlvType != OrganizationExtension {
+		return ErrInvalidTlvType
+	}
+
+	if !bytes.Equal(b[4:7], smpteOrganizationID) {
+		return ErrInvalidTlvOrgId
+	}
+
+	// The value of organizationSubType is 1
+	if !bytes.Equal([]byte{0x0, 0x0, 0x1}, b[7:10]) {
+		return ErrInvalidTlvOrgSubType
+	}
+
+	p.DefaultSystemFrameRate.Numerator = binary.BigEndian.Uint32(b[10:14])
+	p.DefaultSystemFrameRate.Denominator = binary.BigEndian.Uint32(b[14:18])
+
+	p.MasterLockingStatus = MasterLockingStatusType(b[18])
+
+	p.DropFrame = b[19]&0x1 != 0
+	p.ColorFrameIdentification = b[19]&0x2 != 0
+
+	p.CurrentLocalOffset = time.Duration(int32(binary.BigEndian.Uint32(b[20:24]))) * time.Second
+	p.JumpSeconds = time.Duration(int32(binary.BigEndian.Uint32(b[24:28]))) * time.Second
+
+	p.TimeOfNextJump = smpteTime(b[28:34])
+	p.TimeOfNextJam = smpteTime(b[34:40])
+	p.TimeOfPreviousJam = smpteTime(b[40:46])
+
+	p.PreviousJamLocalOffset = time.Duration(int32(binary.BigEndian.Uint32(b[46:50]))) * time.Second
+
+	p.CurrentDaylightSaving = b[50]&0x1 != 0
+	p.DaylightSavingAtNextJump = b[50]&0x2 != 0
+	p.DaylightSavingAtPreviousJam = b[50]&0x4 != 0
+
+	p.LeapSecondJump = b[51]&0x1 != 0
+
+	return nil
+}
+
+// putSmpteTime stores the seconds of t into the 6 bytes of b, 0 for a zero t.
+func putSmpteTime(b []byte, t time.Time) {
+	if t.IsZero() {
+		putUint48(b, 0)
+		return
+	}
+	putUint48(b, uint64(t.Unix()))
+}
+
+// smpteTime returns the time of the seconds in the 6 bytes of b, a zero time
+// for 0.
+func smpteTime(b []byte) time.Time {
+	sec := uint48(b)
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), 0)
+}
+
 type ManagementIdType uint16
 
 const (
@@ -606,7 +1170,7 @@ type SlaveOnlyTlv struct {
 }
 
 type LogAnnounceIntervalTlv struct {
-	LogAnnounceInterval uint8
+	LogAnnounceInterval LogInterval
 	// Reserved 1byte
 }
 
@@ -616,7 +1180,7 @@ type AnnounceReceiptTimeoutTlv struct {
 }
 
 type LogSyncIntervalTlv struct {
-	LogSyncInterval uint8
+	LogSyncInterval LogInterval
 	// Reserved 1byte
 }
 
@@ -679,7 +1243,7 @@ type DelayMechanismTlv struct {
 }
 
 type LogMinPdelayReqIntervalTlv struct {
-	LogMinPdelayReqInterval uint8
+	LogMinPdelayReqInterval LogInterval
 }
 
 type ManagementTlv struct {
@@ -5,6 +5,7 @@ import (
 	"io"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestMarshalPathTraceTlv(t *testing.T) {
@@ -624,3 +625,332 @@ func TestUnmarshalCsnTlv(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalPowerProfileTlv(t *testing.T) {
+	var tests = []struct {
+		desc string
+		m    *PowerProfileTlv
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Correct TLV values",
+			m: &PowerProfileTlv{
+				GrandmasterID:       3,
+				TotalTimeInaccuracy: 250,
+			},
+			b: []byte{0x0, 0x3, 0x0, 0x10,
+				0x1c, 0x12, 0x9d, 0x0, 0x0, 0x2,
+				// grandmasterID
+				0x0, 0x3,
+				// reserved
+				0x0, 0x0, 0x0, 0x0,
+				// totalTimeInaccuracy
+				0x0, 0x0, 0x0, 0xfa},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.m.MarshalBinary()
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalPowerProfileTlv(t *testing.T) {
+	var tests = []struct {
+		desc string
+		m    *PowerProfileTlv
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Correct TLV values",
+			m: &PowerProfileTlv{
+				GrandmasterID:       3,
+				TotalTimeInaccuracy: 250,
+			},
+			b: []byte{0x0, 0x3, 0x0, 0x10,
+				0x1c, 0x12, 0x9d, 0x0, 0x0, 0x2,
+				0x0, 0x3,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0xfa},
+		},
+		{
+			desc: "Invalid organizationId",
+			b: []byte{0x0, 0x3, 0x0, 0x10,
+				0x0, 0x80, 0xc2, 0x0, 0x0, 0x2,
+				0x0, 0x3,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0xfa},
+			err: ErrInvalidTlvOrgId,
+		},
+		{
+			desc: "2011 organizationSubType",
+			b: []byte{0x0, 0x3, 0x0, 0x10,
+				0x1c, 0x12, 0x9d, 0x0, 0x0, 0x1,
+				0x0, 0x3,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0xfa},
+			err: ErrInvalidTlvOrgSubType,
+		},
+		{
+			desc: "Invalid length",
+			b: []byte{0x0, 0x3, 0x0, 0x10,
+				0x1c, 0x12, 0x9d, 0x0, 0x0, 0x2,
+				0x0, 0x3,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0},
+			err: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := new(PowerProfileTlv)
+			err := m.UnmarshalBinary(tt.b)
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.m, m; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalPowerProfile2011Tlv(t *testing.T) {
+	m := &PowerProfile2011Tlv{
+		GrandmasterID:             3,
+		GrandmasterTimeInaccuracy: 50,
+		NetworkTimeInaccuracy:     200,
+	}
+	want := []byte{0x0, 0x3, 0x0, 0x12,
+		0x1c, 0x12, 0x9d, 0x0, 0x0, 0x1,
+		// grandmasterID
+		0x0, 0x3,
+		// grandmasterTimeInaccuracy
+		0x0, 0x0, 0x0, 0x32,
+		// networkTimeInaccuracy
+		0x0, 0x0, 0x0, 0xc8,
+		// reserved
+		0x0, 0x0}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(want, b) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, b)
+	}
+
+	got := new(PowerProfile2011Tlv)
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(m, got) {
+		t.Fatalf("unexpected TLV:\n- want: %#v\n-  got: %#v", m, got)
+	}
+}
+
+func TestAddTimeInaccuracy(t *testing.T) {
+	var tests = []struct {
+		desc string
+		a, b uint32
+		sum  uint32
+	}{
+		{desc: "Simple sum", a: 50, b: 200, sum: 250},
+		{desc: "Unknown stays unknown", a: UnknownTimeInaccuracy, b: 1, sum: UnknownTimeInaccuracy},
+		{desc: "Unknown addend", a: 1, b: UnknownTimeInaccuracy, sum: UnknownTimeInaccuracy},
+		{desc: "Saturation", a: 0xfffffff0, b: 0x20, sum: UnknownTimeInaccuracy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := &AnnounceMsg{
+				PowerProfile:     &PowerProfileTlv{TotalTimeInaccuracy: tt.a},
+				PowerProfile2011: &PowerProfile2011Tlv{NetworkTimeInaccuracy: tt.a},
+			}
+			m.AddTimeInaccuracy(tt.b)
+
+			if want, got := tt.sum, m.PowerProfile.TotalTimeInaccuracy; want != got {
+				t.Fatalf("unexpected totalTimeInaccuracy: %v != %v", want, got)
+			}
+
+			if want, got := tt.sum, m.PowerProfile2011.NetworkTimeInaccuracy; want != got {
+				t.Fatalf("unexpected networkTimeInaccuracy: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+var smpteSyncTlvBytes = []byte{0x0, 0x3, 0x0, 0x30,
+	0x68, 0x97, 0xe8, 0x0, 0x0, 0x1,
+	// defaultSystemFrameRate
+	0x0, 0x0, 0x75, 0x30, 0x0, 0x0, 0x3, 0xe9,
+	// masterLockingStatus
+	0x4,
+	// timeAddressFlags
+	0x1,
+	// currentLocalOffset
+	0xff, 0xff, 0x8f, 0x80,
+	// jumpSeconds
+	0x0, 0x0, 0xe, 0x10,
+	// timeOfNextJump
+	0x0, 0x0, 0x65, 0xf3, 0xe4, 0x0,
+	// timeOfNextJam
+	0x0, 0x0, 0x65, 0x9e, 0x1c, 0x0,
+	// timeOfPreviousJam
+	0x0, 0x0, 0x65, 0x9c, 0xca, 0x80,
+	// previousJamLocalOffset
+	0xff, 0xff, 0x8f, 0x80,
+	// daylightSaving
+	0x2,
+	// leapSecondJump
+	0x0}
+
+var smpteSyncTlv = SmpteSyncTlv{
+	DefaultSystemFrameRate:   FrameRate29_97,
+	MasterLockingStatus:      LockingStatusLocked,
+	DropFrame:                true,
+	CurrentLocalOffset:       -8 * time.Hour,
+	JumpSeconds:              time.Hour,
+	TimeOfNextJump:           time.Unix(1710482432, 0),
+	TimeOfNextJam:            time.Unix(1704860672, 0),
+	TimeOfPreviousJam:        time.Unix(1704774272, 0),
+	PreviousJamLocalOffset:   -8 * time.Hour,
+	DaylightSavingAtNextJump: true,
+}
+
+func TestMarshalSmpteSyncTlv(t *testing.T) {
+	m := smpteSyncTlv
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want, got := smpteSyncTlvBytes, b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
+
+func TestUnmarshalSmpteSyncTlv(t *testing.T) {
+	invalidOrgID := append([]byte{}, smpteSyncTlvBytes...)
+	invalidOrgID[4] = 0x0
+
+	invalidSubType := append([]byte{}, smpteSyncTlvBytes...)
+	invalidSubType[9] = 0x2
+
+	var tests = []struct {
+		desc string
+		m    *SmpteSyncTlv
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Correct TLV values",
+			m:    &smpteSyncTlv,
+			b:    smpteSyncTlvBytes,
+		},
+		{
+			desc: "Invalid organizationId",
+			b:    invalidOrgID,
+			err:  ErrInvalidTlvOrgId,
+		},
+		{
+			desc: "Invalid organizationSubType",
+			b:    invalidSubType,
+			err:  ErrInvalidTlvOrgSubType,
+		},
+		{
+			desc: "Invalid length",
+			b:    smpteSyncTlvBytes[:len(smpteSyncTlvBytes)-1],
+			err:  io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := new(SmpteSyncTlv)
+			err := m.UnmarshalBinary(tt.b)
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("unexpected error: %v != %v", want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.m, m; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected Frame bytes:\n- want: %#v\n-  got: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestSmpteSyncTlvZeroTimes(t *testing.T) {
+	// No jump or jam is scheduled
+	want := SmpteSyncTlv{DefaultSystemFrameRate: FrameRate25, MasterLockingStatus: LockingStatusLocked}
+
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want, got := make([]byte, 18), b[28:46]; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected jump and jam times:\n- want: %#v\n-  got: %#v", want, got)
+	}
+
+	var got SmpteSyncTlv
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected SmpteSyncTlv:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
+
+func TestFrameRate(t *testing.T) {
+	var tests = []struct {
+		desc string
+		r    FrameRate
+		fps  float64
+		d    time.Duration
+	}{
+		{desc: "25 fps", r: FrameRate25, fps: 25, d: 40 * time.Millisecond},
+		{desc: "29.97 fps", r: FrameRate29_97, fps: 30000.0 / 1001, d: 33366666 * time.Nanosecond},
+		{desc: "Zero", r: FrameRate{}, fps: 0, d: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.fps, tt.r.FramesPerSecond(); want != got {
+				t.Fatalf("unexpected frames per second: %v != %v", want, got)
+			}
+
+			if want, got := tt.d, tt.r.FrameDuration(); want != got {
+				t.Fatalf("unexpected frame duration: %v != %v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package ptp
+
+import "fmt"
+
+// Limits of logMessageInterval of periodic messages. IEEE 1588 leaves the
+// ranges to profiles, these cover the default, telecom and gPTP profiles.
+const (
+	minLogMessageInterval LogInterval = -7
+	maxLogMessageInterval LogInterval = 7
+	// noLogMessageInterval is sent by messages not sent periodically
+	noLogMessageInterval = LogIntervalInitial
+)
+
+// maxStepsRemoved is the largest stepsRemoved of a qualified Announce message
+const maxStepsRemoved uint16 = 254
+
+// ValidationError describes a message violating IEEE 1588 rules.
+//
+// Err holds one of the package errors, so ValidationError may be matched with
+// errors.Is.
+type ValidationError struct {
+	// MsgType is the type of the validated message.
+	MsgType MsgType
+	// Field is the IEEE 1588 name of the offending field.
+	Field string
+	// Value is the offending value.
+	Value interface{}
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("messageType %v, %s (%v): %v", e.MsgType, e.Field, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// validate checks the header of a message of type msgType. n is the length of
+// the message in binary form. If tlvs is set, the message may carry TLVs the
+// package does not decode, so messageLength may exceed n.
+func (h *Header) validate(msgType MsgType, n int, tlvs bool) error {
+	if h.MessageType != msgType {
+		return &ValidationError{msgType, "messageType", h.MessageType, ErrInvalidMsgType}
+	}
+
+	if f := h.Faults; f.WrongControlField {
+		return &ValidationError{msgType, "controlField", f.ControlField, ErrInvalidControlField}
+	} else if f.ReservedOffset == 6 {
+		return &ValidationError{msgType, "flagField", f.Reserved, ErrReservedNotZero}
+	} else if f.ReservedOffset != 0 {
+		return &ValidationError{msgType, "reserved", f.Reserved, ErrReservedNotZero}
+	}
+
+	if l := int(h.MessageLength); l < n || (l > n && !tlvs) {
+		return &ValidationError{msgType, "messageLength", h.MessageLength, ErrInvalidMsgLength}
+	}
+
+	// twoStepFlag is defined only for Sync and Pdelay_Resp messages
+	if h.TwoSteps && msgType != SyncMsgType && msgType != PDelayRespMsgType {
+		return &ValidationError{msgType, "flagField.twoStepFlag", h.TwoSteps, ErrInvalidTwoStepFlag}
+	}
+
+	periodic := h.LogMessagePeriod >= minLogMessageInterval && h.LogMessagePeriod <= maxLogMessageInterval
+
+	var valid bool
+	switch msgType {
+	case AnnounceMsgType:
+		valid = periodic
+	case SyncMsgType, FollowUpMsgType, DelayRespMsgType, PDelayReqMsgType:
+		// 0x7f is sent by unicast masters
+		valid = periodic || h.LogMessagePeriod == noLogMessageInterval
+	default:
+		valid = h.LogMessagePeriod == noLogMessageInterval
+	}
+	if !valid {
+		return &ValidationError{msgType, "logMessageInterval", h.LogMessagePeriod, ErrInvalidLogInterval}
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package ptp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	sync := &SyncMsg{
+		Header: Header{
+			MessageType:      SyncMsgType,
+			MessageLength:    HeaderLen + SyncPayloadLen,
+			VersionPTP:       Version2,
+			Flags:            Flags{TwoSteps: true},
+			LogMessagePeriod: -3,
+		},
+		OriginTimestamp: time.Unix(1, 2),
+	}
+	syncBytes, _ := sync.MarshalBinary()
+
+	announce := AnnounceMsg{
+		Header: Header{
+			MessageType:   AnnounceMsgType,
+			MessageLength: HeaderLen + AnnouncePayloadLen,
+			VersionPTP:    Version2,
+			ClockIdentity: 0x000af7fffe42a753,
+		},
+		GMClockQuality: ClockQuality{ClockClass: DefaultClass, ClockAccuracy: ClockAccuracyNotSupported},
+		GMIdentity:     0x000af7fffe42a753,
+		TimeSource:     TimeSourceInternalOsc,
+	}
+
+	delReq := &DelReqMsg{
+		Header: Header{
+			MessageType:      DelayReqMsgType,
+			MessageLength:    HeaderLen + DelayReqPayloadLen,
+			VersionPTP:       Version2,
+			LogMessagePeriod: 0x7f,
+		},
+	}
+
+	decode := func(b []byte) *SyncMsg {
+		m := new(SyncMsg)
+		if err := m.UnmarshalBinary(b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return m
+	}
+
+	var tests = []struct {
+		desc string
+		m    Message
+		err  error
+	}{
+		{
+			desc: "Sync",
+			m:    sync,
+		},
+		{
+			desc: "Decoded Sync",
+			m:    decode(syncBytes),
+		},
+		{
+			desc: "Decoded Sync with wrong controlField",
+			m:    decode(withByte(syncBytes, 32, 0x5)),
+			err:  ErrInvalidControlField,
+		},
+		{
+			desc: "Decoded Sync with reserved byte",
+			m:    decode(withByte(syncBytes, 17, 0x1)),
+			err:  ErrReservedNotZero,
+		},
+		{
+			desc: "Decoded Sync with reserved flag",
+			m:    decode(withByte(syncBytes, 6, 0x12)),
+			err:  ErrReservedNotZero,
+		},
+		{
+			desc: "Decoded Pdelay_Req with reserved body",
+			m: func() Message {
+				m := new(PDelReqMsg)
+				if err := m.UnmarshalBinary(withByte(pdelReqBytes, 50, 0x1)); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return m
+			}(),
+			err: ErrReservedNotZero,
+		},
+		{
+			desc: "Wrong messageType",
+			m:    &SyncMsg{Header: Header{MessageType: FollowUpMsgType, MessageLength: HeaderLen + SyncPayloadLen}},
+			err:  ErrInvalidMsgType,
+		},
+		{
+			desc: "Wrong messageLength",
+			m:    &SyncMsg{Header: Header{MessageType: SyncMsgType, MessageLength: HeaderLen + SyncPayloadLen + 2}},
+			err:  ErrInvalidMsgLength,
+		},
+		{
+			desc: "Delay_Req",
+			m:    delReq,
+		},
+		{
+			desc: "twoStepFlag of Delay_Req",
+			m:    &DelReqMsg{Header: Header{MessageType: DelayReqMsgType, MessageLength: HeaderLen + DelayReqPayloadLen, Flags: Flags{TwoSteps: true}, LogMessagePeriod: 0x7f}},
+			err:  ErrInvalidTwoStepFlag,
+		},
+		{
+			desc: "logMessageInterval of Delay_Req",
+			m:    &DelReqMsg{Header: Header{MessageType: DelayReqMsgType, MessageLength: HeaderLen + DelayReqPayloadLen}},
+			err:  ErrInvalidLogInterval,
+		},
+		{
+			desc: "logMessageInterval of Sync out of range",
+			m:    &SyncMsg{Header: Header{MessageType: SyncMsgType, MessageLength: HeaderLen + SyncPayloadLen, LogMessagePeriod: -8}},
+			err:  ErrInvalidLogInterval,
+		},
+		{
+			desc: "Unicast Sync",
+			m:    &SyncMsg{Header: Header{MessageType: SyncMsgType, MessageLength: HeaderLen + SyncPayloadLen, LogMessagePeriod: 0x7f}},
+		},
+		{
+			desc: "Announce of grandmaster",
+			m:    &announce,
+		},
+		{
+			desc: "Announce with unknown TLV",
+			m: func() Message {
+				m := announce
+				m.MessageLength += 8
+				return &m
+			}(),
+		},
+		{
+			desc: "Announce of boundary clock",
+			m: func() Message {
+				m := announce
+				m.StepsRemoved = 1
+				m.GMIdentity = 0x000af7fffe42a754
+				return &m
+			}(),
+		},
+		{
+			desc: "Announce of boundary clock with its own identity",
+			m: func() Message {
+				m := announce
+				m.StepsRemoved = 1
+				return &m
+			}(),
+			err: ErrInvalidGMIdentity,
+		},
+		{
+			desc: "Announce of grandmaster with other identity",
+			m: func() Message {
+				m := announce
+				m.GMIdentity = 0x000af7fffe42a754
+				return &m
+			}(),
+			err: ErrInvalidGMIdentity,
+		},
+		{
+			desc: "Announce with stepsRemoved 255",
+			m: func() Message {
+				m := announce
+				m.StepsRemoved = 255
+				m.GMIdentity = 0x000af7fffe42a754
+				return &m
+			}(),
+			err: ErrInvalidStepsRemoved,
+		},
+		{
+			desc: "Announce logMessageInterval 0x7f",
+			m: func() Message {
+				m := announce
+				m.LogMessagePeriod = 0x7f
+				return &m
+			}(),
+			err: ErrInvalidLogInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.m.Validate()
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("unexpected error: %v != %v", tt.err, err)
+			}
+		})
+	}
+}
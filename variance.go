@@ -0,0 +1,71 @@
+package ptp
+
+import (
+	"math"
+	"time"
+)
+
+// Encoding of offsetScaledLogVariance, IEEE 1588-2008 7.6.3.3: the log base 2
+// of the PTP variance in s², multiplied by 2^8 and offset by 0x8000.
+const (
+	logVarianceScale  = 1 << 8
+	logVarianceOffset = 0x8000
+	// VarianceUnknown is sent when the variance was not computed or is too
+	// large to be represented.
+	VarianceUnknown uint16 = 0xffff
+)
+
+// PTPVariance returns the PTP variance in s² of the time error samples taken
+// every interval, observed over tau, as defined by IEEE 1588-2008 7.6.3.2:
+// tau²/3 times the Allan variance. tau must be a multiple of interval and
+// is usually the Sync interval.
+//
+// At least 2*tau/interval+1 samples are needed, otherwise ErrNotEnoughSamples
+// is returned.
+func PTPVariance(samples []time.Duration, interval, tau time.Duration) (float64, error) {
+	if interval <= 0 || tau < interval || tau%interval != 0 {
+		return 0, ErrOutOfRange
+	}
+
+	n := int(tau / interval)
+	if len(samples) < 2*n+1 {
+		return 0, ErrNotEnoughSamples
+	}
+
+	// tau² cancels out with the 1/(2tau²) of the Allan variance estimator
+	var sum float64
+	for i := 0; i+2*n < len(samples); i++ {
+		d := (samples[i+2*n] - 2*samples[i+n] + samples[i]).Seconds()
+		sum += d * d
+	}
+
+	return sum / float64(6*(len(samples)-2*n)), nil
+}
+
+// OffsetScaledLogVariance encodes variance in s² as offsetScaledLogVariance.
+// Variances too large to be represented are encoded as VarianceUnknown.
+func OffsetScaledLogVariance(variance float64) uint16 {
+	if math.IsNaN(variance) || variance < 0 {
+		return VarianceUnknown
+	}
+
+	v := math.Round(math.Log2(variance)*logVarianceScale) + logVarianceOffset
+	switch {
+	case v < 0:
+		return 0
+	case v > float64(VarianceUnknown):
+		return VarianceUnknown
+	}
+
+	return uint16(v)
+}
+
+// Variance returns the variance in s² encoded in ClockVariance. It returns
+// +Inf for VarianceUnknown.
+func (p ClockQuality) Variance() float64 {
+	if p.ClockVariance == VarianceUnknown {
+		return math.Inf(1)
+	}
+
+	return math.Exp2(float64(int(p.ClockVariance)-logVarianceOffset) / logVarianceScale)
+}
@@ -0,0 +1,82 @@
+package ptp
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPTPVariance(t *testing.T) {
+	var tests = []struct {
+		desc     string
+		samples  []time.Duration
+		interval time.Duration
+		tau      time.Duration
+		variance float64
+		err      error
+	}{
+		{desc: "Constant offset", samples: []time.Duration{5, 5, 5, 5}, interval: time.Second, tau: time.Second, variance: 0},
+		{desc: "Constant frequency offset", samples: []time.Duration{0, 10, 20, 30}, interval: time.Second, tau: time.Second, variance: 0},
+		// Second differences of 2ns and -2ns
+		{desc: "Alternating", samples: []time.Duration{0, 1, 0, 1}, interval: time.Second, tau: time.Second, variance: 4e-18 / 6},
+		// Second differences over two samples of -2ns and 0
+		{desc: "Tau of two samples", samples: []time.Duration{0, 0, 1, 0, 0, 0}, interval: time.Second, tau: 2 * time.Second,
+			variance: 4e-18 / 12},
+		{desc: "Not enough samples", samples: []time.Duration{0, 0, 0, 0}, interval: time.Second, tau: 2 * time.Second,
+			err: ErrNotEnoughSamples},
+		{desc: "Tau not a multiple", samples: []time.Duration{0, 0, 0}, interval: 2 * time.Second, tau: 3 * time.Second,
+			err: ErrOutOfRange},
+		{desc: "No interval", samples: []time.Duration{0, 0, 0}, err: ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			v, err := PTPVariance(tt.samples, tt.interval, tt.tau)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+
+			if want, got := tt.variance, v; math.Abs(want-got) > 1e-30 {
+				t.Fatalf("unexpected variance: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestOffsetScaledLogVariance(t *testing.T) {
+	var tests = []struct {
+		desc     string
+		variance float64
+		scaled   uint16
+	}{
+		{desc: "1s²", variance: 1, scaled: 0x8000},
+		{desc: "4s²", variance: 4, scaled: 0x8200},
+		{desc: "2^-50s²", variance: math.Exp2(-50), scaled: 0x4e00},
+		{desc: "2^-49.5s²", variance: math.Exp2(-49.5), scaled: 0x4e80},
+		{desc: "Zero", variance: 0, scaled: 0},
+		{desc: "Too large", variance: math.Exp2(128), scaled: VarianceUnknown},
+		{desc: "Infinite", variance: math.Inf(1), scaled: VarianceUnknown},
+		{desc: "NaN", variance: math.NaN(), scaled: VarianceUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			scaled := OffsetScaledLogVariance(tt.variance)
+			if want, got := tt.scaled, scaled; want != got {
+				t.Fatalf("unexpected offsetScaledLogVariance: 0x%04x != 0x%04x", want, got)
+			}
+
+			if tt.scaled == 0 || tt.scaled == VarianceUnknown {
+				return
+			}
+
+			if want, got := tt.variance, (ClockQuality{ClockVariance: scaled}).Variance(); want != got {
+				t.Fatalf("unexpected variance: %v != %v", want, got)
+			}
+		})
+	}
+
+	if got := (ClockQuality{ClockVariance: VarianceUnknown}).Variance(); !math.IsInf(got, 1) {
+		t.Fatalf("unexpected variance of unknown: %v", got)
+	}
+}
@@ -0,0 +1,393 @@
+package ptp
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// HeaderView is a read-only view of a PTP message header within a packet
+// buffer. Accessors decode fields in place, nothing is copied.
+//
+// The buffer must not be modified while the view is in use.
+type HeaderView struct {
+	b []byte
+}
+
+// NewHeaderView checks that b starts with a valid PTP header and returns a
+// view over it.
+//
+// If b is shorter than a header, io.ErrUnexpectedEOF is returned.
+func NewHeaderView(b []byte) (HeaderView, error) {
+	if err := checkHeader(b); err != nil {
+		return HeaderView{}, err
+	}
+
+	return HeaderView{b: b}, nil
+}
+
+// checkHeader validates the header at the start of b the same way
+// Header.UnmarshalBinary does.
+func checkHeader(b []byte) error {
+	if len(b) < HeaderLen {
+		return io.ErrUnexpectedEOF
+	}
+
+	if !isValidMsgType(MsgType(b[0] & 0x0f)) {
+		return ErrInvalidMsgType
+	}
+
+	if ProtoVersion(b[1]&0x0f) != Version2 {
+		return ErrUnsupportedVersion
+	}
+
+	return nil
+}
+
+// newMsgView validates the header of b, its message type and the length of
+// the fixed part of the message.
+func newMsgView(b []byte, msgType MsgType, payloadLen int) (HeaderView, error) {
+	if len(b) < HeaderLen+payloadLen {
+		return HeaderView{}, io.ErrUnexpectedEOF
+	}
+
+	v, err := NewHeaderView(b)
+	if err != nil {
+		return HeaderView{}, err
+	}
+
+	if v.MessageType() != msgType {
+		return HeaderView{}, ErrInvalidMsgType
+	}
+
+	return v, nil
+}
+
+// Bytes returns the underlying packet buffer.
+func (v HeaderView) Bytes() []byte {
+	return v.b
+}
+
+// MessageType returns messageType field.
+func (v HeaderView) MessageType() MsgType {
+	return MsgType(v.b[0] & 0x0f)
+}
+
+// VersionPTP returns versionPTP field.
+func (v HeaderView) VersionPTP() ProtoVersion {
+	return ProtoVersion(v.b[1] & 0x0f)
+}
+
+// MessageLength returns messageLength field.
+func (v HeaderView) MessageLength() uint16 {
+	return binary.BigEndian.Uint16(v.b[2:4])
+}
+
+// DomainNumber returns domainNumber field.
+func (v HeaderView) DomainNumber() uint8 {
+	return v.b[4]
+}
+
+// Flags returns flagField.
+func (v HeaderView) Flags() Flags {
+	var f Flags
+	f.UnmarshalBinary(v.b[6:8])
+	return f
+}
+
+// CorrectionNs returns nanoseconds part of correctionField.
+func (v HeaderView) CorrectionNs() uint64 {
+	return uint48(v.b[8:14])
+}
+
+// CorrectionSubNs returns sub-nanoseconds part of correctionField.
+func (v HeaderView) CorrectionSubNs() uint16 {
+	return binary.BigEndian.Uint16(v.b[14:16])
+}
+
+// Correction returns correctionField truncated to nanoseconds.
+func (v HeaderView) Correction() time.Duration {
+	return time.Duration(int64(binary.BigEndian.Uint64(v.b[8:16])) >> 16)
+}
+
+// ClockIdentity returns clockIdentity of sourcePortIdentity.
+func (v HeaderView) ClockIdentity() uint64 {
+	return binary.BigEndian.Uint64(v.b[20:28])
+}
+
+// PortNumber returns portNumber of sourcePortIdentity.
+func (v HeaderView) PortNumber() uint16 {
+	return binary.BigEndian.Uint16(v.b[28:30])
+}
+
+// SequenceID returns sequenceId field.
+func (v HeaderView) SequenceID() uint16 {
+	return binary.BigEndian.Uint16(v.b[30:32])
+}
+
+// ControlField returns controlField.
+func (v HeaderView) ControlField() MsgCtrlType {
+	return MsgCtrlType(v.b[32])
+}
+
+// LogMessagePeriod returns logMessageInterval field.
+func (v HeaderView) LogMessagePeriod() LogInterval {
+	return LogInterval(v.b[33])
+}
+
+// portIdentity decodes the port identity at offset.
+func (v HeaderView) portIdentity(offset int) PortIdentity {
+	return PortIdentity{
+		ClockIdentity: binary.BigEndian.Uint64(v.b[offset : offset+ClockIdentityLen]),
+		PortNumber:    binary.BigEndian.Uint16(v.b[offset+ClockIdentityLen : offset+ClockIdentityLen+SourcePortNumberLen]),
+	}
+}
+
+// timestamp decodes the timestamp at offset. The view was validated to be
+// long enough on construction.
+func (v HeaderView) timestamp(offset int) time.Time {
+	t, _ := originTimestamp2Time(v.b[offset : offset+OriginTimestampFullLen])
+	return t
+}
+
+// SyncView is a read-only view of a Sync message.
+type SyncView struct {
+	HeaderView
+}
+
+// NewSyncView checks that b holds a Sync message and returns a view over it.
+func NewSyncView(b []byte) (SyncView, error) {
+	v, err := newMsgView(b, SyncMsgType, SyncPayloadLen)
+	return SyncView{v}, err
+}
+
+// OriginTimestamp returns originTimestamp field.
+func (v SyncView) OriginTimestamp() time.Time {
+	return v.timestamp(HeaderLen)
+}
+
+// DelReqView is a read-only view of a Delay_Req message.
+type DelReqView struct {
+	HeaderView
+}
+
+// NewDelReqView checks that b holds a Delay_Req message and returns a view
+// over it.
+func NewDelReqView(b []byte) (DelReqView, error) {
+	v, err := newMsgView(b, DelayReqMsgType, DelayReqPayloadLen)
+	return DelReqView{v}, err
+}
+
+// OriginTimestamp returns originTimestamp field.
+func (v DelReqView) OriginTimestamp() time.Time {
+	return v.timestamp(HeaderLen)
+}
+
+// FollowUpView is a read-only view of a Follow_Up message.
+type FollowUpView struct {
+	HeaderView
+}
+
+// NewFollowUpView checks that b holds a Follow_Up message and returns a view
+// over it.
+func NewFollowUpView(b []byte) (FollowUpView, error) {
+	v, err := newMsgView(b, FollowUpMsgType, FollowUpPayloadLen)
+	return FollowUpView{v}, err
+}
+
+// PreciseOriginTimestamp returns preciseOriginTimestamp field.
+func (v FollowUpView) PreciseOriginTimestamp() time.Time {
+	return v.timestamp(HeaderLen)
+}
+
+// DelRespView is a read-only view of a Delay_Resp message.
+type DelRespView struct {
+	HeaderView
+}
+
+// NewDelRespView checks that b holds a Delay_Resp message and returns a view
+// over it.
+func NewDelRespView(b []byte) (DelRespView, error) {
+	v, err := newMsgView(b, DelayRespMsgType, DelayRespPayloadLen)
+	return DelRespView{v}, err
+}
+
+// ReceiveTimestamp returns receiveTimestamp field.
+func (v DelRespView) ReceiveTimestamp() time.Time {
+	return v.timestamp(HeaderLen)
+}
+
+// RequestingPortIdentity returns clockIdentity of requestingPortIdentity.
+func (v DelRespView) RequestingPortIdentity() uint64 {
+	offset := HeaderLen + OriginTimestampFullLen
+	return binary.BigEndian.Uint64(v.b[offset : offset+ClockIdentityLen])
+}
+
+// RequestingPortID returns portNumber of requestingPortIdentity.
+func (v DelRespView) RequestingPortID() uint16 {
+	offset := HeaderLen + OriginTimestampFullLen + ClockIdentityLen
+	return binary.BigEndian.Uint16(v.b[offset : offset+SourcePortNumberLen])
+}
+
+// PDelReqView is a read-only view of a Pdelay_Req message.
+type PDelReqView struct {
+	HeaderView
+}
+
+// NewPDelReqView checks that b holds a Pdelay_Req message and returns a view
+// over it.
+func NewPDelReqView(b []byte) (PDelReqView, error) {
+	v, err := newMsgView(b, PDelayReqMsgType, PDelayReqPayloadLen)
+	return PDelReqView{v}, err
+}
+
+// PDelRespView is a read-only view of a Pdelay_Resp message.
+type PDelRespView struct {
+	HeaderView
+}
+
+// NewPDelRespView checks that b holds a Pdelay_Resp message and returns a
+// view over it.
+func NewPDelRespView(b []byte) (PDelRespView, error) {
+	v, err := newMsgView(b, PDelayRespMsgType, PDelayRespPayloadLen)
+	return PDelRespView{v}, err
+}
+
+// ReceiveTimestamp returns requestReceiptTimestamp field.
+func (v PDelRespView) ReceiveTimestamp() time.Time {
+	return v.timestamp(HeaderLen)
+}
+
+// RequestingPortIdentity returns requestingPortIdentity field.
+func (v PDelRespView) RequestingPortIdentity() PortIdentity {
+	return v.portIdentity(HeaderLen + OriginTimestampFullLen)
+}
+
+// PDelRespFollowUpView is a read-only view of a Pdelay_Resp_Follow_Up
+// message.
+type PDelRespFollowUpView struct {
+	HeaderView
+}
+
+// NewPDelRespFollowUpView checks that b holds a Pdelay_Resp_Follow_Up
+// message and returns a view over it.
+func NewPDelRespFollowUpView(b []byte) (PDelRespFollowUpView, error) {
+	v, err := newMsgView(b, PDelayRespFollowUpMsgType, PDelayRespFollowUpPayloadLen)
+	return PDelRespFollowUpView{v}, err
+}
+
+// OriginTimestamp returns responseOriginTimestamp field.
+func (v PDelRespFollowUpView) OriginTimestamp() time.Time {
+	return v.timestamp(HeaderLen)
+}
+
+// RequestingPortIdentity returns requestingPortIdentity field.
+func (v PDelRespFollowUpView) RequestingPortIdentity() PortIdentity {
+	return v.portIdentity(HeaderLen + OriginTimestampFullLen)
+}
+
+// AnnounceView is a read-only view of an Announce message.
+type AnnounceView struct {
+	HeaderView
+}
+
+// NewAnnounceView checks that b holds an Announce message and returns a view
+// over it.
+func NewAnnounceView(b []byte) (AnnounceView, error) {
+	v, err := newMsgView(b, AnnounceMsgType, AnnouncePayloadLen)
+	return AnnounceView{v}, err
+}
+
+// OriginTimestamp returns originTimestamp field.
+func (v AnnounceView) OriginTimestamp() time.Time {
+	return v.timestamp(HeaderLen)
+}
+
+// CurrentUtcOffset returns currentUtcOffset field.
+func (v AnnounceView) CurrentUtcOffset() int16 {
+	return int16(binary.BigEndian.Uint16(v.b[44:46]))
+}
+
+// GMPriority1 returns grandmasterPriority1 field.
+func (v AnnounceView) GMPriority1() uint8 {
+	return v.b[47]
+}
+
+// GMClockQuality returns grandmasterClockQuality field. Unlike
+// ClockQuality.UnmarshalBinary, the values are not validated.
+func (v AnnounceView) GMClockQuality() ClockQuality {
+	return ClockQuality{
+		ClockClass:    ClockClassType(v.b[48]),
+		ClockAccuracy: ClockAccuracyType(v.b[49]),
+		ClockVariance: binary.BigEndian.Uint16(v.b[50:52]),
+	}
+}
+
+// GMPriority2 returns grandmasterPriority2 field.
+func (v AnnounceView) GMPriority2() uint8 {
+	return v.b[52]
+}
+
+// GMIdentity returns grandmasterIdentity field.
+func (v AnnounceView) GMIdentity() uint64 {
+	return binary.BigEndian.Uint64(v.b[53:61])
+}
+
+// StepsRemoved returns stepsRemoved field.
+func (v AnnounceView) StepsRemoved() uint16 {
+	return binary.BigEndian.Uint16(v.b[61:63])
+}
+
+// TimeSource returns timeSource field.
+func (v AnnounceView) TimeSource() TimeSourceType {
+	return TimeSourceType(v.b[63])
+}
+
+// SignalingView is a read-only view of a Signaling message.
+type SignalingView struct {
+	HeaderView
+}
+
+// NewSignalingView checks that b holds a Signaling message and returns a view
+// over it.
+func NewSignalingView(b []byte) (SignalingView, error) {
+	v, err := newMsgView(b, SignalingMsgType, SignalingPayloadLen)
+	return SignalingView{v}, err
+}
+
+// TargetPortIdentity returns targetPortIdentity field.
+func (v SignalingView) TargetPortIdentity() PortIdentity {
+	return v.portIdentity(HeaderLen)
+}
+
+// MgmtView is a read-only view of a Management message.
+type MgmtView struct {
+	HeaderView
+}
+
+// NewMgmtView checks that b holds a Management message and returns a view
+// over it.
+func NewMgmtView(b []byte) (MgmtView, error) {
+	v, err := newMsgView(b, MgmtMsgType, MgmtPayloadLen)
+	return MgmtView{v}, err
+}
+
+// TargetPortIdentity returns targetPortIdentity field.
+func (v MgmtView) TargetPortIdentity() PortIdentity {
+	return v.portIdentity(HeaderLen)
+}
+
+// StartingBoundaryHops returns startingBoundaryHops field.
+func (v MgmtView) StartingBoundaryHops() uint8 {
+	return v.b[HeaderLen+PortIdentityLen]
+}
+
+// BoundaryHops returns boundaryHops field.
+func (v MgmtView) BoundaryHops() uint8 {
+	return v.b[HeaderLen+PortIdentityLen+1]
+}
+
+// ActionField returns actionField.
+func (v MgmtView) ActionField() ActionFiledType {
+	return ActionFiledType(v.b[HeaderLen+PortIdentityLen+2] & 0x0f)
+}
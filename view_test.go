@@ -0,0 +1,335 @@
+package ptp
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHeaderView(t *testing.T) {
+	var tests = []struct {
+		desc string
+		h    *Header
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "Correct structure",
+			h: &Header{
+				MessageType:      FollowUpMsgType,
+				MessageLength:    44,
+				VersionPTP:       Version2,
+				Flags:            Flags{TwoSteps: true, UtcReasonable: true},
+				CorrectionNs:     0x0102030405,
+				CorrectionSubNs:  0x8000,
+				ClockIdentity:    0x000af7fffe42a753,
+				PortNumber:       2,
+				SequenceID:       55330,
+				LogMessagePeriod: -4,
+			},
+			b: []byte{0x8, 0x2, 0x0, 0x2c, 0x0, 0x0, 0x2, 0x4,
+				0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x80, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x2, 0xfc,
+				// Trailing payload is not part of the header
+				0x1, 0x2, 0x3},
+		},
+		{
+			desc: "Invalid message type",
+			b: []byte{0x4, 0x2, 0x0, 0x2c, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x5, 0xfc},
+			err: ErrInvalidMsgType,
+		},
+		{
+			desc: "Invalid version",
+			b: []byte{0x2, 0x1, 0x0, 0x2c, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0xa, 0xf7, 0xff, 0xfe, 0x42, 0xa7, 0x53, 0x0, 0x2, 0xd8, 0x22, 0x5, 0xfc},
+			err: ErrUnsupportedVersion,
+		},
+		{
+			desc: "Invalid length",
+			b:    []byte{0x2, 0x2, 0x0, 0x2c, 0x0, 0x0, 0x0, 0x0},
+			err:  io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			v, err := NewHeaderView(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("unexpected error: %v != %v", want, got)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got := &Header{
+				Flags:            v.Flags(),
+				MessageType:      v.MessageType(),
+				MessageLength:    v.MessageLength(),
+				VersionPTP:       v.VersionPTP(),
+				CorrectionNs:     v.CorrectionNs(),
+				CorrectionSubNs:  v.CorrectionSubNs(),
+				ClockIdentity:    v.ClockIdentity(),
+				PortNumber:       v.PortNumber(),
+				SequenceID:       v.SequenceID(),
+				LogMessagePeriod: v.LogMessagePeriod(),
+			}
+
+			if want := tt.h; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected Header:\n- want: %#v\n-  got: %#v", want, got)
+			}
+
+			if want, got := time.Duration(0x0102030405), v.Correction(); want != got {
+				t.Fatalf("unexpected correction: %v != %v", want, got)
+			}
+
+			if want, got := FollowUpMsgCtrlType, v.ControlField(); want != got {
+				t.Fatalf("unexpected control field: %v != %v", want, got)
+			}
+		})
+	}
+}
+
+func TestMessageViews(t *testing.T) {
+	header := Header{
+		ClockIdentity:    0x000af7fffe42a753,
+		PortNumber:       2,
+		SequenceID:       55330,
+		LogMessagePeriod: -4,
+	}
+	withType := func(t MsgType) Header {
+		h := header
+		h.MessageType = t
+		return h
+	}
+	ts := time.Unix(1169232201, 775045731)
+
+	t.Run("Sync", func(t *testing.T) {
+		m := &SyncMsg{Header: withType(SyncMsgType), OriginTimestamp: ts}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewSyncView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := m.SequenceID, v.SequenceID(); want != got {
+			t.Fatalf("unexpected sequenceId: %v != %v", want, got)
+		}
+
+		if want, got := ts, v.OriginTimestamp(); !want.Equal(got) {
+			t.Fatalf("unexpected originTimestamp: %v != %v", want, got)
+		}
+
+		if _, err := NewFollowUpView(b); err != ErrInvalidMsgType {
+			t.Fatalf("unexpected error: %v != %v", ErrInvalidMsgType, err)
+		}
+
+		if _, err := NewSyncView(b[:len(b)-1]); err != io.ErrUnexpectedEOF {
+			t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+		}
+	})
+
+	t.Run("DelReq", func(t *testing.T) {
+		m := &DelReqMsg{Header: withType(DelayReqMsgType), OriginTimestamp: ts}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewDelReqView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := ts, v.OriginTimestamp(); !want.Equal(got) {
+			t.Fatalf("unexpected originTimestamp: %v != %v", want, got)
+		}
+	})
+
+	t.Run("FollowUp", func(t *testing.T) {
+		m := &FollowUpMsg{Header: withType(FollowUpMsgType), PreciseOriginTimestamp: ts}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewFollowUpView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := ts, v.PreciseOriginTimestamp(); !want.Equal(got) {
+			t.Fatalf("unexpected preciseOriginTimestamp: %v != %v", want, got)
+		}
+	})
+
+	t.Run("DelResp", func(t *testing.T) {
+		m := &DelRespMsg{Header: withType(DelayRespMsgType), ReceiveTimestamp: ts, RequestingPortIdentity: 0x11, RequestingPortID: 3}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewDelRespView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := ts, v.ReceiveTimestamp(); !want.Equal(got) {
+			t.Fatalf("unexpected receiveTimestamp: %v != %v", want, got)
+		}
+
+		if v.RequestingPortIdentity() != 0x11 || v.RequestingPortID() != 3 {
+			t.Fatalf("unexpected requestingPortIdentity: %x %d", v.RequestingPortIdentity(), v.RequestingPortID())
+		}
+	})
+
+	t.Run("PDelReq", func(t *testing.T) {
+		m := &PDelReqMsg{Header: withType(PDelayReqMsgType)}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewPDelReqView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := m.MessageLength, v.MessageLength(); want != got {
+			t.Fatalf("unexpected messageLength: %v != %v", want, got)
+		}
+	})
+
+	t.Run("PDelResp", func(t *testing.T) {
+		m := &PDelRespMsg{Header: withType(PDelayRespMsgType), ReceiveTimestamp: ts, ClockIdentity: 0x22, PortNumber: 4}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewPDelRespView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := ts, v.ReceiveTimestamp(); !want.Equal(got) {
+			t.Fatalf("unexpected requestReceiptTimestamp: %v != %v", want, got)
+		}
+
+		if want, got := (PortIdentity{0x22, 4}), v.RequestingPortIdentity(); want != got {
+			t.Fatalf("unexpected requestingPortIdentity: %v != %v", want, got)
+		}
+
+		if want, got := header.ClockIdentity, v.ClockIdentity(); want != got {
+			t.Fatalf("unexpected source clockIdentity: %v != %v", want, got)
+		}
+	})
+
+	t.Run("PDelRespFollowUp", func(t *testing.T) {
+		m := &PDelRespFollowUpMsg{Header: withType(PDelayRespFollowUpMsgType), OriginTimestamp: ts, ClockIdentity: 0x22, PortNumber: 4}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewPDelRespFollowUpView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := ts, v.OriginTimestamp(); !want.Equal(got) {
+			t.Fatalf("unexpected responseOriginTimestamp: %v != %v", want, got)
+		}
+
+		if want, got := (PortIdentity{0x22, 4}), v.RequestingPortIdentity(); want != got {
+			t.Fatalf("unexpected requestingPortIdentity: %v != %v", want, got)
+		}
+	})
+
+	t.Run("Announce", func(t *testing.T) {
+		m := AnnounceMsg{
+			Header:           withType(AnnounceMsgType),
+			GMClockQuality:   ClockQuality{ClockClass: PrimarySyncRefClass, ClockAccuracy: ClockAccuracy100ns, ClockVariance: 200},
+			CurrentUtcOffset: 37,
+			GMPriority1:      128,
+			GMPriority2:      127,
+			GMIdentity:       0x001d7ffffe80024a,
+			StepsRemoved:     3,
+			TimeSource:       TimeSourceGPS,
+		}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewAnnounceView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := AnnounceMsg{
+			Header:           withType(AnnounceMsgType),
+			GMClockQuality:   v.GMClockQuality(),
+			CurrentUtcOffset: v.CurrentUtcOffset(),
+			GMPriority1:      v.GMPriority1(),
+			GMPriority2:      v.GMPriority2(),
+			GMIdentity:       v.GMIdentity(),
+			StepsRemoved:     v.StepsRemoved(),
+			TimeSource:       v.TimeSource(),
+		}
+
+		if !reflect.DeepEqual(m, got) {
+			t.Fatalf("unexpected Announce:\n- want: %#v\n-  got: %#v", m, got)
+		}
+	})
+
+	t.Run("Signaling", func(t *testing.T) {
+		m := &SignalingMsg{Header: withType(SignalingMsgType), ClockIdentity: 0x33, PortNumber: 5}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewSignalingView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := (PortIdentity{0x33, 5}), v.TargetPortIdentity(); want != got {
+			t.Fatalf("unexpected targetPortIdentity: %v != %v", want, got)
+		}
+	})
+
+	t.Run("Mgmt", func(t *testing.T) {
+		m := MgmtMsg{Header: withType(MgmtMsgType), ClockIdentity: 0x44, PortNumber: 6, StartingBoundaryHops: 2, BoundaryHops: 1, ActionField: Response}
+		b, _ := m.MarshalBinary()
+
+		v, err := NewMgmtView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want, got := (PortIdentity{0x44, 6}), v.TargetPortIdentity(); want != got {
+			t.Fatalf("unexpected targetPortIdentity: %v != %v", want, got)
+		}
+
+		if v.StartingBoundaryHops() != 2 || v.BoundaryHops() != 1 || v.ActionField() != Response {
+			t.Fatalf("unexpected management fields: %d %d %d", v.StartingBoundaryHops(), v.BoundaryHops(), v.ActionField())
+		}
+	})
+}
+
+func TestViewZeroAlloc(t *testing.T) {
+	m := &SyncMsg{Header: Header{MessageType: SyncMsgType, SequenceID: 1}, OriginTimestamp: time.Unix(500, 200)}
+	b, _ := m.MarshalBinary()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		v, err := NewSyncView(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_ = v.SequenceID()
+		_ = v.Correction()
+		_ = v.OriginTimestamp()
+	})
+	if allocs != 0 {
+		t.Fatalf("unexpected allocations per view: %v", allocs)
+	}
+}
+
+func BenchmarkSyncView(b *testing.B) {
+	m := &SyncMsg{Header: Header{MessageType: SyncMsgType, SequenceID: 1}, OriginTimestamp: time.Unix(500, 200)}
+	buf, _ := m.MarshalBinary()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v, _ := NewSyncView(buf)
+		_ = v.SequenceID()
+		_ = v.OriginTimestamp()
+	}
+}